@@ -16,8 +16,15 @@ limitations under the License.
 package v1
 
 import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/names"
+	"github.com/tektoncd/pipeline/pkg/substitution"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 // Step runs a subcomponent of a Task
@@ -119,6 +126,21 @@ type Step struct {
 	// +optional
 	Timeout *metav1.Duration `json:"timeout,omitempty"`
 
+	// This is an alpha field. You must set the "enable-api-fields" feature flag to "alpha"
+	// for this field to be supported.
+	//
+	// Retries is the number of times the step is run again if it fails. Defaults to 0.
+	// +optional
+	Retries int `json:"retries,omitempty"`
+
+	// This is an alpha field. You must set the "enable-api-fields" feature flag to "alpha"
+	// for this field to be supported.
+	//
+	// RetryBackoff is the time to wait before each retry of the step. Defaults to retrying immediately.
+	// Refer to Go's ParseDuration documentation for expected format: https://golang.org/pkg/time/#ParseDuration
+	// +optional
+	RetryBackoff *metav1.Duration `json:"retryBackoff,omitempty"`
+
 	// This is an alpha field. You must set the "enable-api-fields" feature flag to "alpha"
 	// for this field to be supported.
 	//
@@ -130,11 +152,123 @@ type Step struct {
 	// +listType=atomic
 	Workspaces []WorkspaceUsage `json:"workspaces,omitempty"`
 
+	// This is an alpha field. You must set the "enable-api-fields" feature flag to "alpha"
+	// for this field to be supported.
+	//
+	// When is a list of when expressions that need to be true for the step to run.
+	// The step is skipped if any of them is false.
+	// +optional
+	// +listType=atomic
+	When WhenExpressions `json:"when,omitempty"`
+
+	// This is an alpha field. You must set the "enable-api-fields" feature flag to "alpha"
+	// for this field to be supported.
+	//
+	// RestartPolicy can only be set to "Always", which declares the step as a native Kubernetes
+	// sidecar: a restartable init container that is started before the other steps and keeps
+	// running alongside them. Native sidecar steps must come before all the other steps.
+	// +optional
+	RestartPolicy *corev1.RestartPolicy `json:"restartPolicy,omitempty"`
+
 	// OnError defines the exiting behavior of a container on error
 	// can be set to [ continue | stopAndFail ]
 	// stopAndFail indicates exit the taskRun if the container exits with non-zero exit code
 	// continue indicates continue executing the rest of the steps irrespective of the container exit code
 	OnError string `json:"onError,omitempty"`
+
+	// Stores configuration for the stdout stream of the step.
+	// +optional
+	StdoutConfig *StepOutputConfig `json:"stdoutConfig,omitempty"`
+	// Stores configuration for the stderr stream of the step.
+	// +optional
+	StderrConfig *StepOutputConfig `json:"stderrConfig,omitempty"`
+}
+
+// Valid values of Step.OnError.
+const (
+	// StepOnErrorContinue continues executing the rest of the steps irrespective of the step's exit code.
+	StepOnErrorContinue = "continue"
+	// StepOnErrorStopAndFail stops the TaskRun and fails it if the step exits with a non-zero exit code.
+	// It's the behavior when OnError isn't set.
+	StepOnErrorStopAndFail = "stopAndFail"
+)
+
+// ContinuesOnError returns true if the rest of the steps are executed even if the Step fails.
+func (s *Step) ContinuesOnError() bool {
+	return s.OnError == StepOnErrorContinue
+}
+
+// IsNativeSidecar returns true if the Step's RestartPolicy declares it as a native Kubernetes sidecar.
+func (s *Step) IsNativeSidecar() bool {
+	return s.RestartPolicy != nil && *s.RestartPolicy == corev1.RestartPolicyAlways
+}
+
+// IsPrivileged returns true if the Step's SecurityContext runs it in privileged mode. Adding capabilities
+// doesn't make a Step privileged.
+func (s *Step) IsPrivileged() bool {
+	return s.SecurityContext != nil && s.SecurityContext.Privileged != nil && *s.SecurityContext.Privileged
+}
+
+// ShouldRun returns true if all of the Step's when expressions are true once the values of
+// params, keyed by param name, are substituted into them. A Step without when expressions
+// always runs.
+func (s *Step) ShouldRun(params map[string]string) bool {
+	replacements := make(map[string]string, len(params))
+	for name, value := range params {
+		replacements[fmt.Sprintf("%s.%s", ParamsPrefix, name)] = value
+	}
+	return s.When.ReplaceWhenExpressionsVariables(replacements, nil).AllowsExecution()
+}
+
+// applyReplacements interpolates the string replacements into the fields of the Step that may
+// contain variables.
+func (s *Step) applyReplacements(replacements map[string]string) {
+	s.Name = substitution.ApplyReplacements(s.Name, replacements)
+	s.Image = substitution.ApplyReplacements(s.Image, replacements)
+	s.WorkingDir = substitution.ApplyReplacements(s.WorkingDir, replacements)
+	s.Script = substitution.ApplyReplacements(s.Script, replacements)
+	for i := range s.Command {
+		s.Command[i] = substitution.ApplyReplacements(s.Command[i], replacements)
+	}
+	for i := range s.Args {
+		s.Args[i] = substitution.ApplyReplacements(s.Args[i], replacements)
+	}
+	for i := range s.Env {
+		s.Env[i].Value = substitution.ApplyReplacements(s.Env[i].Value, replacements)
+	}
+	for i := range s.VolumeMounts {
+		s.VolumeMounts[i].MountPath = substitution.ApplyReplacements(s.VolumeMounts[i].MountPath, replacements)
+		s.VolumeMounts[i].SubPath = substitution.ApplyReplacements(s.VolumeMounts[i].SubPath, replacements)
+	}
+	if s.StdoutConfig != nil {
+		s.StdoutConfig.Path = substitution.ApplyReplacements(s.StdoutConfig.Path, replacements)
+	}
+	if s.StderrConfig != nil {
+		s.StderrConfig.Path = substitution.ApplyReplacements(s.StderrConfig.Path, replacements)
+	}
+}
+
+// StepOutputConfig stores configuration for a step output stream.
+type StepOutputConfig struct {
+	// Path to duplicate stdout stream to on container's local filesystem.
+	// +optional
+	Path string `json:"path,omitempty"`
+}
+
+// stdoutResultPathRegex matches a stdout path that is exactly the path of a Task result.
+var stdoutResultPathRegex = regexp.MustCompile(`^\$\(results\.([_a-zA-Z0-9-]+)\.path\)$`)
+
+// StdoutResult returns the name of the Task result the Step's stdout is captured into, which is
+// the case when the path of its StdoutConfig is $(results.<name>.path).
+func (s *Step) StdoutResult() (name string, ok bool) {
+	if s.StdoutConfig == nil {
+		return "", false
+	}
+	match := stdoutResultPathRegex.FindStringSubmatch(s.StdoutConfig.Path)
+	if match == nil {
+		return "", false
+	}
+	return match[1], true
 }
 
 // ToK8sContainer converts the Step to a Kubernetes Container struct
@@ -171,6 +305,54 @@ func (s *Step) SetContainerFields(c corev1.Container) {
 	s.SecurityContext = c.SecurityContext
 }
 
+// ContainerName returns the name of the container that runs the Step at the given index of its Task:
+// "step-" followed by the Step name, or "step-unnamed-<index>" if the Step has no name, shortened
+// to the maximum valid container name length if needed.
+func (s *Step) ContainerName(index int) string {
+	name := fmt.Sprintf("step-unnamed-%d", index)
+	if s.Name != "" {
+		name = "step-" + s.Name
+	}
+	return names.SimpleNameGenerator.RestrictLength(name)
+}
+
+// RetryPolicy returns the number of times the Step is retried if it fails and the time to
+// wait before each retry. Both are zero if the Step doesn't configure retries.
+func (s *Step) RetryPolicy() (count int, backoff time.Duration) {
+	if s.Retries > 0 {
+		count = s.Retries
+	}
+	if s.RetryBackoff != nil {
+		backoff = s.RetryBackoff.Duration
+	}
+	return count, backoff
+}
+
+// EffectiveStepTimeout returns the time the step may run for, given the timeout of
+// the Task it belongs to and the time that has already elapsed running the Task.
+// It's the smaller of the step's own timeout and what remains of the Task's timeout.
+// A zero timeout means the step has no deadline, which is only the case if neither
+// timeout is set. If the Task's timeout has already expired, expired is true and the
+// step must not run at all.
+func EffectiveStepTimeout(step Step, taskTimeout *metav1.Duration, elapsed time.Duration) (timeout time.Duration, expired bool) {
+	var stepTimeout time.Duration
+	if step.Timeout != nil {
+		stepTimeout = step.Timeout.Duration
+	}
+	if taskTimeout == nil || taskTimeout.Duration == 0 {
+		return stepTimeout, false
+	}
+
+	remaining := taskTimeout.Duration - elapsed
+	if remaining <= 0 {
+		return 0, true
+	}
+	if stepTimeout > 0 && stepTimeout < remaining {
+		return stepTimeout, false
+	}
+	return remaining, false
+}
+
 // StepTemplate is a template for a Step
 type StepTemplate struct {
 
@@ -288,6 +470,28 @@ func (s *StepTemplate) ToK8sContainer() *corev1.Container {
 	}
 }
 
+// HasProjectedToken returns true if the StepTemplate mounts one of volumes that projects a service
+// account token. The template only refers to volumes by name, so the Task's volumes must be given.
+func (s *StepTemplate) HasProjectedToken(volumes []corev1.Volume) bool {
+	tokenVolumes := sets.NewString()
+	for _, v := range volumes {
+		if v.Projected == nil {
+			continue
+		}
+		for _, source := range v.Projected.Sources {
+			if source.ServiceAccountToken != nil {
+				tokenVolumes.Insert(v.Name)
+			}
+		}
+	}
+	for _, vm := range s.VolumeMounts {
+		if tokenVolumes.Has(vm.Name) {
+			return true
+		}
+	}
+	return false
+}
+
 // Sidecar has nearly the same data structure as Step but does not have the ability to timeout.
 type Sidecar struct {
 
@@ -473,6 +677,12 @@ type Sidecar struct {
 	Workspaces []WorkspaceUsage `json:"workspaces,omitempty"`
 }
 
+// RequiresReady returns true if the Sidecar declares a readiness or startup probe, in which case
+// the Steps don't start until the probe has succeeded.
+func (s *Sidecar) RequiresReady() bool {
+	return s.ReadinessProbe != nil || s.StartupProbe != nil
+}
+
 // ToK8sContainer converts the Sidecar to a Kubernetes Container struct
 func (s *Sidecar) ToK8sContainer() *corev1.Container {
 	return &corev1.Container{
@@ -0,0 +1,406 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestEffectiveStepTimeout(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		step        v1.Step
+		taskTimeout *metav1.Duration
+		elapsed     time.Duration
+		want        time.Duration
+		wantExpired bool
+	}{{
+		name: "no timeouts",
+		step: v1.Step{},
+		want: 0,
+	}, {
+		name: "step timeout only",
+		step: v1.Step{Timeout: &metav1.Duration{Duration: time.Minute}},
+		want: time.Minute,
+	}, {
+		name:        "task timeout only",
+		step:        v1.Step{},
+		taskTimeout: &metav1.Duration{Duration: time.Hour},
+		elapsed:     10 * time.Minute,
+		want:        50 * time.Minute,
+	}, {
+		name:        "zero task timeout means no task timeout",
+		step:        v1.Step{Timeout: &metav1.Duration{Duration: time.Minute}},
+		taskTimeout: &metav1.Duration{Duration: 0},
+		elapsed:     10 * time.Minute,
+		want:        time.Minute,
+	}, {
+		name:        "both set and step timeout is smaller",
+		step:        v1.Step{Timeout: &metav1.Duration{Duration: time.Minute}},
+		taskTimeout: &metav1.Duration{Duration: time.Hour},
+		elapsed:     10 * time.Minute,
+		want:        time.Minute,
+	}, {
+		name:        "both set and remaining task timeout is smaller",
+		step:        v1.Step{Timeout: &metav1.Duration{Duration: time.Hour}},
+		taskTimeout: &metav1.Duration{Duration: time.Hour},
+		elapsed:     50 * time.Minute,
+		want:        10 * time.Minute,
+	}, {
+		name:        "task timeout expired",
+		step:        v1.Step{Timeout: &metav1.Duration{Duration: time.Minute}},
+		taskTimeout: &metav1.Duration{Duration: time.Hour},
+		elapsed:     2 * time.Hour,
+		wantExpired: true,
+	}, {
+		name:        "task timeout expired exactly",
+		step:        v1.Step{},
+		taskTimeout: &metav1.Duration{Duration: time.Hour},
+		elapsed:     time.Hour,
+		wantExpired: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, expired := v1.EffectiveStepTimeout(tc.step, tc.taskTimeout, tc.elapsed)
+			if expired != tc.wantExpired {
+				t.Errorf("EffectiveStepTimeout() expired = %t, want %t", expired, tc.wantExpired)
+			}
+			if !expired && got != tc.want {
+				t.Errorf("EffectiveStepTimeout() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSidecar_RequiresReady(t *testing.T) {
+	probe := &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt(8080)},
+		},
+	}
+	for _, tc := range []struct {
+		name    string
+		sidecar v1.Sidecar
+		want    bool
+	}{{
+		name:    "no probes",
+		sidecar: v1.Sidecar{Image: "my-image"},
+		want:    false,
+	}, {
+		name:    "liveness probe only",
+		sidecar: v1.Sidecar{Image: "my-image", LivenessProbe: probe},
+		want:    false,
+	}, {
+		name:    "tcp readiness probe",
+		sidecar: v1.Sidecar{Image: "my-image", ReadinessProbe: probe},
+		want:    true,
+	}, {
+		name:    "startup probe",
+		sidecar: v1.Sidecar{Image: "my-image", StartupProbe: probe},
+		want:    true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.sidecar.RequiresReady(); got != tc.want {
+				t.Errorf("RequiresReady() = %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestStep_RetryPolicy(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		step        v1.Step
+		wantCount   int
+		wantBackoff time.Duration
+	}{{
+		name: "defaults to no retries",
+		step: v1.Step{Image: "my-image"},
+	}, {
+		name:      "retries without backoff",
+		step:      v1.Step{Image: "my-image", Retries: 3},
+		wantCount: 3,
+	}, {
+		name:        "retries with backoff",
+		step:        v1.Step{Image: "my-image", Retries: 2, RetryBackoff: &metav1.Duration{Duration: 5 * time.Second}},
+		wantCount:   2,
+		wantBackoff: 5 * time.Second,
+	}, {
+		name: "negative retries are treated as none",
+		step: v1.Step{Image: "my-image", Retries: -1},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			count, backoff := tc.step.RetryPolicy()
+			if count != tc.wantCount {
+				t.Errorf("RetryPolicy() count = %d, want %d", count, tc.wantCount)
+			}
+			if backoff != tc.wantBackoff {
+				t.Errorf("RetryPolicy() backoff = %s, want %s", backoff, tc.wantBackoff)
+			}
+		})
+	}
+}
+
+func TestStep_ContainerFieldsRoundTrip(t *testing.T) {
+	want := corev1.Container{
+		Name:            "my-step",
+		Image:           "my-image",
+		Command:         []string{"cmd"},
+		Args:            []string{"arg"},
+		WorkingDir:      "/workspace",
+		EnvFrom:         []corev1.EnvFromSource{{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "cm"}}}},
+		Env:             []corev1.EnvVar{{Name: "FOO", Value: "bar"}},
+		Resources:       corev1.ResourceRequirements{Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")}},
+		VolumeMounts:    []corev1.VolumeMount{{Name: "vol", MountPath: "/vol"}},
+		VolumeDevices:   []corev1.VolumeDevice{{Name: "dev", DevicePath: "/dev/xvda"}},
+		ImagePullPolicy: corev1.PullAlways,
+		SecurityContext: &corev1.SecurityContext{RunAsNonRoot: &[]bool{true}[0]},
+	}
+
+	step := v1.Step{}
+	step.SetContainerFields(want)
+	if d := cmp.Diff(want, *step.ToK8sContainer()); d != "" {
+		t.Errorf("Step container fields round trip %s", diff.PrintWantGot(d))
+	}
+
+	stepTemplate := v1.StepTemplate{}
+	stepTemplate.SetContainerFields(want)
+	wantTemplate := *want.DeepCopy()
+	// StepTemplates don't carry a name.
+	wantTemplate.Name = ""
+	if d := cmp.Diff(wantTemplate, *stepTemplate.ToK8sContainer()); d != "" {
+		t.Errorf("StepTemplate container fields round trip %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestStep_ContinuesOnError(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		onError string
+		want    bool
+	}{{
+		name: "not set defaults to stopAndFail",
+		want: false,
+	}, {
+		name:    "continue",
+		onError: v1.StepOnErrorContinue,
+		want:    true,
+	}, {
+		name:    "stopAndFail",
+		onError: v1.StepOnErrorStopAndFail,
+		want:    false,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			step := v1.Step{Image: "my-image", OnError: tc.onError}
+			if got := step.ContinuesOnError(); got != tc.want {
+				t.Errorf("ContinuesOnError() = %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestStep_StdoutResult(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		stdoutConfig *v1.StepOutputConfig
+		wantName     string
+		wantOK       bool
+	}{{
+		name:   "no stdout config",
+		wantOK: false,
+	}, {
+		name:         "stdout captured into a result",
+		stdoutConfig: &v1.StepOutputConfig{Path: "$(results.digest.path)"},
+		wantName:     "digest",
+		wantOK:       true,
+	}, {
+		name:         "stdout written to a file",
+		stdoutConfig: &v1.StepOutputConfig{Path: "/tmp/stdout.txt"},
+		wantOK:       false,
+	}, {
+		name:         "stdout written next to a result",
+		stdoutConfig: &v1.StepOutputConfig{Path: "$(results.digest.path).log"},
+		wantOK:       false,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			step := v1.Step{Image: "my-image", StdoutConfig: tc.stdoutConfig}
+			name, ok := step.StdoutResult()
+			if name != tc.wantName || ok != tc.wantOK {
+				t.Errorf("StdoutResult() = (%q, %t), want (%q, %t)", name, ok, tc.wantName, tc.wantOK)
+			}
+		})
+	}
+}
+
+func TestStep_IsPrivileged(t *testing.T) {
+	privileged, unprivileged := true, false
+	for _, tc := range []struct {
+		name            string
+		securityContext *corev1.SecurityContext
+		want            bool
+	}{{
+		name: "no security context",
+		want: false,
+	}, {
+		name:            "privileged",
+		securityContext: &corev1.SecurityContext{Privileged: &privileged},
+		want:            true,
+	}, {
+		name:            "explicitly unprivileged",
+		securityContext: &corev1.SecurityContext{Privileged: &unprivileged},
+		want:            false,
+	}, {
+		name: "added capabilities",
+		securityContext: &corev1.SecurityContext{
+			Capabilities: &corev1.Capabilities{Add: []corev1.Capability{"NET_ADMIN"}},
+		},
+		want: false,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			step := v1.Step{Image: "my-image", SecurityContext: tc.securityContext}
+			if got := step.IsPrivileged(); got != tc.want {
+				t.Errorf("IsPrivileged() = %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestStep_ShouldRun(t *testing.T) {
+	params := map[string]string{"mode": "debug"}
+	for _, tc := range []struct {
+		name string
+		when v1.WhenExpressions
+		want bool
+	}{{
+		name: "no when expressions",
+		want: true,
+	}, {
+		name: "in expression matches",
+		when: v1.WhenExpressions{{
+			Input:    "$(params.mode)",
+			Operator: selection.In,
+			Values:   []string{"debug", "trace"},
+		}},
+		want: true,
+	}, {
+		name: "in expression doesn't match",
+		when: v1.WhenExpressions{{
+			Input:    "$(params.mode)",
+			Operator: selection.In,
+			Values:   []string{"release"},
+		}},
+		want: false,
+	}, {
+		name: "notin expression with param in values",
+		when: v1.WhenExpressions{{
+			Input:    "debug",
+			Operator: selection.NotIn,
+			Values:   []string{"$(params.mode)"},
+		}},
+		want: false,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			step := v1.Step{Image: "my-image", When: tc.when}
+			original := step.DeepCopy()
+			if got := step.ShouldRun(params); got != tc.want {
+				t.Errorf("ShouldRun() = %t, want %t", got, tc.want)
+			}
+			if d := cmp.Diff(original, &step); d != "" {
+				t.Errorf("ShouldRun() modified the step %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestStepTemplate_HasProjectedToken(t *testing.T) {
+	volumes := []corev1.Volume{{
+		Name: "sa-token",
+		VolumeSource: corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{
+			Sources: []corev1.VolumeProjection{{
+				ServiceAccountToken: &corev1.ServiceAccountTokenProjection{Audience: "vault", Path: "token"},
+			}},
+		}},
+	}, {
+		Name: "config",
+		VolumeSource: corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{
+			Sources: []corev1.VolumeProjection{{
+				ConfigMap: &corev1.ConfigMapProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "cm"}},
+			}},
+		}},
+	}, {
+		Name:         "data",
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+	}}
+	for _, tc := range []struct {
+		name         string
+		volumeMounts []corev1.VolumeMount
+		want         bool
+	}{{
+		name: "no volume mounts",
+	}, {
+		name:         "mounts a projected token",
+		volumeMounts: []corev1.VolumeMount{{Name: "data", MountPath: "/data"}, {Name: "sa-token", MountPath: "/var/run/secrets/tokens"}},
+		want:         true,
+	}, {
+		name:         "mounts a projected volume without a token",
+		volumeMounts: []corev1.VolumeMount{{Name: "config", MountPath: "/config"}},
+	}, {
+		name:         "mounts an undeclared volume",
+		volumeMounts: []corev1.VolumeMount{{Name: "missing", MountPath: "/missing"}},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			st := v1.StepTemplate{VolumeMounts: tc.volumeMounts}
+			if got := st.HasProjectedToken(volumes); got != tc.want {
+				t.Errorf("HasProjectedToken() = %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestStepContainerName(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		step  v1.Step
+		index int
+		want  string
+	}{{
+		name:  "named step",
+		step:  v1.Step{Name: "build"},
+		index: 2,
+		want:  "step-build",
+	}, {
+		name:  "unnamed step",
+		step:  v1.Step{Image: "busybox"},
+		index: 2,
+		want:  "step-unnamed-2",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.step.ContainerName(tc.index); got != tc.want {
+				t.Errorf("ContainerName(%d) = %q, want %q", tc.index, got, tc.want)
+			}
+		})
+	}
+}
@@ -18,6 +18,9 @@ package v1
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/strategicpatch"
@@ -32,39 +35,363 @@ type mergeData struct {
 	patchSchema  strategicpatch.PatchMetaFromStruct
 }
 
-// MergeStepsWithStepTemplate takes a possibly nil container template and a
-// list of steps, merging each of the steps with the container template, if
-// it's not nil, and returning the resulting list.
-func MergeStepsWithStepTemplate(template *StepTemplate, steps []Step) ([]Step, error) {
+// Stages of merging an object with a template, reported by MergeError.
+const (
+	MergeStageCreatePatch = "create merge patch"
+	MergeStageApplyPatch  = "apply merge patch"
+	MergeStageUnmarshal   = "unmarshal merged object"
+)
+
+// MergeError is returned when an object such as a Step can't be merged with its template.
+// +k8s:deepcopy-gen=false
+// +k8s:openapi-gen=false
+type MergeError struct {
+	// Index is the position of the object in the list being merged.
+	Index int
+	// Name is the name of the object being merged, if any.
+	Name string
+	// Stage is the stage of the merge that failed.
+	Stage    string
+	original error
+}
+
+var _ error = &MergeError{}
+
+// Error returns the string representation of this error.
+func (e *MergeError) Error() string {
+	return fmt.Sprintf("failed to %s for %q at index %d: %v", e.Stage, e.Name, e.Index, e.original)
+}
+
+// Unwrap returns the underlying original error.
+func (e *MergeError) Unwrap() error {
+	return e.original
+}
+
+// withMergeTarget records the index and name of the object being merged on err
+// if it's a MergeError, and returns err.
+func withMergeTarget(err error, index int, name string) error {
+	var me *MergeError
+	if errors.As(err, &me) {
+		me.Index = index
+		me.Name = name
+	}
+	return err
+}
+
+// StepTemplateMerger merges a StepTemplate into lists of steps. The template is
+// serialized once when the merger is created, so a single merger can be reused to
+// merge the steps of many Tasks that share the same template.
+// +k8s:deepcopy-gen=false
+// +k8s:openapi-gen=false
+type StepTemplateMerger struct {
+	md           *mergeData
+	args         []string
+	envFrom      []corev1.EnvFromSource
+	capabilities *corev1.Capabilities
+}
+
+// arrayParamExpansionRegex matches references that expand a whole array param, e.g. `$(params.flags[*])`.
+var arrayParamExpansionRegex = regexp.MustCompile(`\$\(` + ParamsPrefix + `\.[_a-zA-Z0-9.-]+\[\*\]\)`)
+
+// NewStepTemplateMerger returns a StepTemplateMerger for the possibly nil template.
+func NewStepTemplateMerger(template *StepTemplate) (*StepTemplateMerger, error) {
 	if template == nil {
-		return steps, nil
+		return &StepTemplateMerger{}, nil
 	}
 
 	md, err := getMergeData(template.ToK8sContainer(), &corev1.Container{})
 	if err != nil {
 		return nil, err
 	}
+	m := &StepTemplateMerger{md: md, args: template.Args, envFrom: template.EnvFrom}
+	if template.SecurityContext != nil {
+		m.capabilities = template.SecurityContext.Capabilities
+	}
+	return m, nil
+}
+
+// Merge merges each of the steps with the merger's template, if it's not nil,
+// and returns the resulting list.
+func (m *StepTemplateMerger) Merge(steps []Step) ([]Step, error) {
+	if m.md == nil {
+		return steps, nil
+	}
 
 	for i, s := range steps {
-		merged := corev1.Container{}
-		err := mergeObjWithTemplateBytes(md, s.ToK8sContainer(), &merged)
+		merged, err := m.mergeContainer(s.ToK8sContainer())
+		if err != nil {
+			return nil, withMergeTarget(err, i, s.Name)
+		}
+
+		// Pass through the original step's fields that aren't part of the container, e.g. Script,
+		// for later conversion. The step is deep copied so that the merged step owns all of its memory.
+		newStep := s.DeepCopy()
+		newStep.SetContainerFields(merged)
+		steps[i] = *newStep
+	}
+	return steps, nil
+}
+
+// mergeContainer merges the container of a step or sidecar with the merger's template, which
+// must not be nil, and returns the result.
+func (m *StepTemplateMerger) mergeContainer(c *corev1.Container) (corev1.Container, error) {
+	merged := corev1.Container{}
+	if err := mergeObjWithTemplateBytes(m.md, c, &merged); err != nil {
+		return merged, err
+	}
+
+	// If the container's args is nil, reset it to empty instead
+	if merged.Args == nil && c.Args != nil {
+		merged.Args = []string{}
+	}
+
+	// Args are replaced as a whole by the merge, but a container that expands an array param
+	// into its args only supplies part of the list, so keep the template args in front.
+	if len(m.args) > 0 && expandsArrayParam(c.Args) {
+		merged.Args = append(append([]string{}, m.args...), c.Args...)
+	}
+
+	// Env vars declared by the container always win over the template's, even if empty.
+	merged.Env = DedupeEnv(overrideEnv(merged.Env, c.Env))
+	merged.EnvFrom = mergeEnvFrom(m.envFrom, c.EnvFrom, merged.EnvFrom)
+
+	if c.SecurityContext != nil {
+		mergeCapabilities(merged.SecurityContext, m.capabilities, c.SecurityContext.Capabilities)
+	}
+	return merged, nil
+}
+
+// MergeStepsWithStepTemplate takes a possibly nil container template and a
+// list of steps, merging each of the steps with the container template, if
+// it's not nil, and returning the resulting list.
+// The steps are merged in place, so the returned list shares its backing array
+// with steps. Use MergedSteps to leave steps untouched.
+func MergeStepsWithStepTemplate(template *StepTemplate, steps []Step) ([]Step, error) {
+	m, err := NewStepTemplateMerger(template)
+	if err != nil {
+		return nil, err
+	}
+	return m.Merge(steps)
+}
+
+// MergedSteps is like MergeStepsWithStepTemplate, but it doesn't modify steps.
+// The merged steps are returned in a newly allocated list.
+func MergedSteps(template *StepTemplate, steps []Step) ([]Step, error) {
+	if steps == nil {
+		return nil, nil
+	}
+	copied := make([]Step, len(steps))
+	for i := range steps {
+		steps[i].DeepCopyInto(&copied[i])
+	}
+	return MergeStepsWithStepTemplate(template, copied)
+}
+
+// MergeStepTemplates merges the templates from left to right, so that the fields set by later
+// templates win, the same way a step's fields win when it's merged with a template. Nil templates
+// are skipped, and nil is returned if all of them are nil. The templates aren't modified.
+func MergeStepTemplates(templates ...*StepTemplate) (*StepTemplate, error) {
+	var merged *StepTemplate
+	for i, t := range templates {
+		if t == nil {
+			continue
+		}
+		if merged == nil {
+			merged = t.DeepCopy()
+			continue
+		}
+		m, err := NewStepTemplateMerger(merged)
 		if err != nil {
 			return nil, err
 		}
+		// Merge the template as if it was a step, to get the same handling of args, env and capabilities.
+		s := Step{}
+		s.SetContainerFields(*t.ToK8sContainer())
+		steps, err := m.Merge([]Step{s})
+		if err != nil {
+			return nil, withMergeTarget(err, i, "")
+		}
+		merged = &StepTemplate{}
+		merged.SetContainerFields(*steps[0].ToK8sContainer())
+	}
+	return merged, nil
+}
+
+// MergeSidecarsWithSidecarTemplate takes a possibly nil container template and a
+// list of sidecars, merging each of the sidecars with the container template, if
+// it's not nil, and returning the resulting list.
+func MergeSidecarsWithSidecarTemplate(template *StepTemplate, sidecars []Sidecar) ([]Sidecar, error) {
+	if template == nil {
+		return sidecars, nil
+	}
+
+	m, err := NewStepTemplateMerger(template)
+	if err != nil {
+		return nil, err
+	}
 
-		// If the container's args is nil, reset it to empty instead
-		if merged.Args == nil && s.Args != nil {
-			merged.Args = []string{}
+	for i, s := range sidecars {
+		merged, err := m.mergeContainer(s.ToK8sContainer())
+		if err != nil {
+			return nil, withMergeTarget(err, i, s.Name)
 		}
 
-		// Pass through original step Script, for later conversion.
-		newStep := Step{Script: s.Script, OnError: s.OnError, Timeout: s.Timeout}
-		newStep.SetContainerFields(merged)
-		steps[i] = newStep
+		// Pass through the original sidecar's fields that aren't part of the container, e.g. Script,
+		// for later conversion. The sidecar is deep copied so that the merged sidecar owns all of its memory.
+		newSidecar := s.DeepCopy()
+		newSidecar.SetContainerFields(merged)
+		sidecars[i] = *newSidecar
+	}
+	return sidecars, nil
+}
+
+// MergeStepsWithSpecs takes a possibly nil list of overrides and a list of steps,
+// merging each of the steps with the overrides' resource requirements, if it's
+// not nil, and returning the resulting list. It returns an error if an override
+// refers to a step that doesn't exist.
+func MergeStepsWithSpecs(steps []Step, overrides []TaskRunStepSpec) ([]Step, error) {
+	if len(overrides) == 0 {
+		return steps, nil
+	}
+	ts := &TaskSpec{Steps: steps}
+	for _, o := range overrides {
+		s, i := ts.StepByName(o.Name)
+		if s == nil {
+			return nil, fmt.Errorf("step %q referenced by step override does not exist", o.Name)
+		}
+		merged := corev1.ResourceRequirements{}
+		err := mergeObjWithTemplate(&s.Resources, &o.ComputeResources, &merged)
+		if err != nil {
+			return nil, withMergeTarget(err, i, o.Name)
+		}
+		s.Resources = merged
 	}
 	return steps, nil
 }
 
+// expandsArrayParam returns true if any of args expands a whole array param.
+func expandsArrayParam(args []string) bool {
+	for _, a := range args {
+		if arrayParamExpansionRegex.MatchString(a) {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeEnvFrom returns the template's envFrom sources followed by the container's own. Strategic
+// merge replaces envFrom as a whole since it has no merge key, so merged is only returned as is
+// when one of the lists is empty. A source referenced by both is only kept in the container's
+// position, so that its variables still take precedence.
+func mergeEnvFrom(template, own, merged []corev1.EnvFromSource) []corev1.EnvFromSource {
+	if len(template) == 0 || len(own) == 0 {
+		return merged
+	}
+	ownSources := make(map[string]bool, len(own))
+	for _, e := range own {
+		ownSources[envFromSourceKey(e)] = true
+	}
+	result := make([]corev1.EnvFromSource, 0, len(template)+len(own))
+	for _, e := range template {
+		if !ownSources[envFromSourceKey(e)] {
+			result = append(result, *e.DeepCopy())
+		}
+	}
+	for _, e := range own {
+		result = append(result, *e.DeepCopy())
+	}
+	return result
+}
+
+// envFromSourceKey identifies the ConfigMap or Secret that an envFrom source refers to.
+func envFromSourceKey(e corev1.EnvFromSource) string {
+	switch {
+	case e.ConfigMapRef != nil:
+		return fmt.Sprintf("configMap/%s/%s", e.ConfigMapRef.Name, e.Prefix)
+	case e.SecretRef != nil:
+		return fmt.Sprintf("secret/%s/%s", e.SecretRef.Name, e.Prefix)
+	}
+	return e.Prefix
+}
+
+// mergeCapabilities sets the capabilities in merged to the union of the template's and the
+// container's own Add and Drop lists. Strategic merge replaces each list as a whole, so the
+// template's capabilities would otherwise be lost whenever the container sets the same list.
+func mergeCapabilities(merged *corev1.SecurityContext, template, own *corev1.Capabilities) {
+	if merged == nil || template == nil || own == nil {
+		return
+	}
+	merged.Capabilities = &corev1.Capabilities{
+		Add:  unionCapabilities(template.Add, own.Add),
+		Drop: unionCapabilities(template.Drop, own.Drop),
+	}
+}
+
+// unionCapabilities returns the capabilities in a followed by those in b that aren't in a.
+func unionCapabilities(a, b []corev1.Capability) []corev1.Capability {
+	if len(a) == 0 && len(b) == 0 {
+		return nil
+	}
+	union := make([]corev1.Capability, 0, len(a)+len(b))
+	seen := make(map[corev1.Capability]bool, len(a)+len(b))
+	for _, c := range append(append([]corev1.Capability{}, a...), b...) {
+		if !seen[c] {
+			seen[c] = true
+			union = append(union, c)
+		}
+	}
+	return union
+}
+
+// overrideEnv replaces every entry in merged that has the same name as an entry in
+// overrides with the entry from overrides. Strategic merge treats an empty value as
+// absent, which would otherwise let the template value leak through.
+func overrideEnv(merged, overrides []corev1.EnvVar) []corev1.EnvVar {
+	if len(overrides) == 0 {
+		return merged
+	}
+	byName := make(map[string]corev1.EnvVar, len(overrides))
+	for _, e := range overrides {
+		byName[e.Name] = e
+	}
+	for i, e := range merged {
+		if o, ok := byName[e.Name]; ok {
+			merged[i] = *o.DeepCopy()
+		}
+	}
+	return merged
+}
+
+// DedupeEnv returns env with a single entry for each name. The last entry declared with a name
+// wins, as it does when the container runs, but it takes the position of the first one, so that
+// the order of the names in env is preserved. env isn't modified.
+func DedupeEnv(env []corev1.EnvVar) []corev1.EnvVar {
+	if env == nil {
+		return nil
+	}
+	positions := make(map[string]int, len(env))
+	deduped := make([]corev1.EnvVar, 0, len(env))
+	for _, e := range env {
+		if i, ok := positions[e.Name]; ok {
+			deduped[i] = *e.DeepCopy()
+			continue
+		}
+		positions[e.Name] = len(deduped)
+		deduped = append(deduped, *e.DeepCopy())
+	}
+	return deduped
+}
+
+// mergeObjWithTemplate merges obj with template and updates out to reflect the merged result.
+// template, obj, and out should point to the same type. out points to the zero value of that type.
+func mergeObjWithTemplate(template, obj, out interface{}) error {
+	md, err := getMergeData(template, out)
+	if err != nil {
+		return err
+	}
+	return mergeObjWithTemplateBytes(md, obj, out)
+}
+
 // getMergeData serializes the template and empty object to get the intermediate results necessary for
 // merging an object of the same type with this template.
 // This function is provided to avoid repeatedly serializing an identical template.
@@ -103,14 +430,17 @@ func mergeObjWithTemplateBytes(md *mergeData, obj, out interface{}) error {
 	// the "patchMerge" tags.
 	patch, err := strategicpatch.CreateThreeWayMergePatch(md.emptyJSON, objAsJSON, md.templateJSON, md.patchSchema, true)
 	if err != nil {
-		return err
+		return &MergeError{Stage: MergeStageCreatePatch, original: err}
 	}
 
 	// Actually apply the merge patch to the template JSON.
 	mergedAsJSON, err := strategicpatch.StrategicMergePatchUsingLookupPatchMeta(md.templateJSON, patch, md.patchSchema)
 	if err != nil {
-		return err
+		return &MergeError{Stage: MergeStageApplyPatch, original: err}
 	}
 	// Unmarshal the merged JSON to a pointer, and return it.
-	return json.Unmarshal(mergedAsJSON, out)
+	if err := json.Unmarshal(mergedAsJSON, out); err != nil {
+		return &MergeError{Stage: MergeStageUnmarshal, original: err}
+	}
+	return nil
 }
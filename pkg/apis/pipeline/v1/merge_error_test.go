@@ -0,0 +1,50 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestStepTemplateMerger_MergeError(t *testing.T) {
+	md, err := getMergeData(&corev1.Container{}, &corev1.Container{})
+	if err != nil {
+		t.Fatalf("unexpected error getting merge data: %v", err)
+	}
+	// Corrupt the serialized template so that creating the patch fails.
+	md.templateJSON = []byte("{")
+	m := &StepTemplateMerger{md: md}
+
+	_, err = m.Merge([]Step{{Name: "foo"}, {Name: "bar"}})
+	var me *MergeError
+	if !errors.As(err, &me) {
+		t.Fatalf("expected a *MergeError, got %T: %v", err, err)
+	}
+	if me.Index != 0 || me.Name != "foo" || me.Stage != MergeStageCreatePatch {
+		t.Errorf("unexpected MergeError fields: index %d, name %q, stage %q", me.Index, me.Name, me.Stage)
+	}
+	if errors.Unwrap(err) == nil {
+		t.Errorf("expected MergeError to wrap the original error")
+	}
+	if !strings.Contains(err.Error(), `"foo"`) {
+		t.Errorf("expected error message to name the step, got %q", err.Error())
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package v1_test
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -73,6 +74,48 @@ func TestMergeStepsWithStepTemplate(t *testing.T) {
 			Command: []string{"/somecmd"},
 			Image:   "some-other-image",
 		}},
+	}, {
+		name: "empty-step-working-dir-inherits-template-working-dir",
+		template: &v1.StepTemplate{
+			WorkingDir: "/workspace",
+		},
+		steps: []v1.Step{{
+			Name:       "foo",
+			Image:      "some-image",
+			WorkingDir: "",
+		}, {
+			Name:       "bar",
+			Image:      "some-image",
+			WorkingDir: "/workspace/src",
+		}},
+		expected: []v1.Step{{
+			Name:       "foo",
+			Image:      "some-image",
+			WorkingDir: "/workspace",
+		}, {
+			Name:       "bar",
+			Image:      "some-image",
+			WorkingDir: "/workspace/src",
+		}},
+	}, {
+		name: "empty-step-image-inherits-template-image",
+		template: &v1.StepTemplate{
+			Image: "some-image",
+		},
+		steps: []v1.Step{{
+			Name:  "foo",
+			Image: "",
+		}, {
+			Name:  "bar",
+			Image: "some-other-image",
+		}},
+		expected: []v1.Step{{
+			Name:  "foo",
+			Image: "some-image",
+		}, {
+			Name:  "bar",
+			Image: "some-other-image",
+		}},
 	}, {
 		name: "merge-and-overwrite-slice",
 		template: &v1.StepTemplate{
@@ -105,6 +148,281 @@ func TestMergeStepsWithStepTemplate(t *testing.T) {
 				Value: "NEW_VALUE",
 			}},
 		}},
+	}, {
+		name: "workspace-and-output-config",
+		template: &v1.StepTemplate{
+			VolumeMounts: []corev1.VolumeMount{{
+				Name:      "data",
+				MountPath: "/workspace/data",
+			}},
+		},
+		steps: []v1.Step{{
+			Image:        "some-image",
+			Workspaces:   []v1.WorkspaceUsage{{Name: "data"}},
+			StdoutConfig: &v1.StepOutputConfig{Path: "stdout.txt"},
+			StderrConfig: &v1.StepOutputConfig{Path: "stderr.txt"},
+		}},
+		expected: []v1.Step{{
+			Image:        "some-image",
+			Workspaces:   []v1.WorkspaceUsage{{Name: "data"}},
+			StdoutConfig: &v1.StepOutputConfig{Path: "stdout.txt"},
+			StderrConfig: &v1.StepOutputConfig{Path: "stderr.txt"},
+			VolumeMounts: []corev1.VolumeMount{{
+				Name:      "data",
+				MountPath: "/workspace/data",
+			}},
+		}},
+	}, {
+		name: "template-args-replaced-by-step-args",
+		template: &v1.StepTemplate{
+			Args: []string{"--verbose"},
+		},
+		steps: []v1.Step{{
+			Image: "some-image",
+			Args:  []string{"foo", "$(params.bar)"},
+		}},
+		expected: []v1.Step{{
+			Image: "some-image",
+			Args:  []string{"foo", "$(params.bar)"},
+		}},
+	}, {
+		name: "template-args-prepended-to-array-param-expansion",
+		template: &v1.StepTemplate{
+			Args: []string{"--verbose"},
+		},
+		steps: []v1.Step{{
+			Image: "some-image",
+			Args:  []string{"build", "$(params.flags[*])", "--output"},
+		}, {
+			Image: "some-image",
+		}},
+		expected: []v1.Step{{
+			Image: "some-image",
+			Args:  []string{"--verbose", "build", "$(params.flags[*])", "--output"},
+		}, {
+			Image: "some-image",
+			Args:  []string{"--verbose"},
+		}},
+	}, {
+		name: "step-env-with-empty-value-overrides-template",
+		template: &v1.StepTemplate{
+			Env: []corev1.EnvVar{{
+				Name:  "FOO",
+				Value: "bar",
+			}},
+		},
+		steps: []v1.Step{{
+			Env: []corev1.EnvVar{{
+				Name:  "FOO",
+				Value: "",
+			}},
+		}},
+		expected: []v1.Step{{
+			Env: []corev1.EnvVar{{
+				Name:  "FOO",
+				Value: "",
+			}},
+		}},
+	}, {
+		name: "projected-token-volume-mount-survives",
+		template: &v1.StepTemplate{
+			VolumeMounts: []corev1.VolumeMount{{
+				Name:      "sa-token",
+				MountPath: "/var/run/secrets/tokens",
+				ReadOnly:  true,
+			}},
+		},
+		steps: []v1.Step{{
+			Image: "some-image",
+			VolumeMounts: []corev1.VolumeMount{{
+				Name:      "data",
+				MountPath: "/data",
+			}},
+		}},
+		expected: []v1.Step{{
+			Image: "some-image",
+			VolumeMounts: []corev1.VolumeMount{{
+				Name:      "data",
+				MountPath: "/data",
+			}, {
+				Name:      "sa-token",
+				MountPath: "/var/run/secrets/tokens",
+				ReadOnly:  true,
+			}},
+		}},
+	}, {
+		name: "duplicate-step-env-deduped",
+		template: &v1.StepTemplate{
+			Env: []corev1.EnvVar{{
+				Name:  "FOO",
+				Value: "template",
+			}, {
+				Name:  "BAR",
+				Value: "template",
+			}},
+		},
+		steps: []v1.Step{{
+			Env: []corev1.EnvVar{{
+				Name:  "FOO",
+				Value: "first",
+			}, {
+				Name:  "FOO",
+				Value: "second",
+			}},
+		}},
+		expected: []v1.Step{{
+			Env: []corev1.EnvVar{{
+				Name:  "FOO",
+				Value: "second",
+			}, {
+				Name:  "BAR",
+				Value: "template",
+			}},
+		}},
+	}, {
+		name: "step-env-value-from-replaces-template-value",
+		template: &v1.StepTemplate{
+			Env: []corev1.EnvVar{{
+				Name:  "FOO",
+				Value: "bar",
+			}},
+		},
+		steps: []v1.Step{{
+			Env: []corev1.EnvVar{{
+				Name: "FOO",
+				ValueFrom: &corev1.EnvVarSource{
+					FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"},
+				},
+			}},
+		}},
+		expected: []v1.Step{{
+			Env: []corev1.EnvVar{{
+				Name: "FOO",
+				ValueFrom: &corev1.EnvVarSource{
+					FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"},
+				},
+			}},
+		}},
+	}, {
+		name: "env-from-sources-merged",
+		template: &v1.StepTemplate{
+			EnvFrom: []corev1.EnvFromSource{{
+				ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "cm-a"}},
+			}, {
+				SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "sec-shared"}},
+			}},
+		},
+		steps: []v1.Step{{
+			Image: "some-image",
+			EnvFrom: []corev1.EnvFromSource{{
+				SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "sec-shared"}},
+			}, {
+				SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "sec-b"}},
+			}},
+		}, {
+			Image: "some-image",
+		}},
+		expected: []v1.Step{{
+			Image: "some-image",
+			EnvFrom: []corev1.EnvFromSource{{
+				ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "cm-a"}},
+			}, {
+				SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "sec-shared"}},
+			}, {
+				SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "sec-b"}},
+			}},
+		}, {
+			Image: "some-image",
+			EnvFrom: []corev1.EnvFromSource{{
+				ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "cm-a"}},
+			}, {
+				SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "sec-shared"}},
+			}},
+		}},
+	}, {
+		name: "capabilities-add-and-drop-merged",
+		template: &v1.StepTemplate{
+			SecurityContext: &corev1.SecurityContext{
+				Capabilities: &corev1.Capabilities{
+					Add: []corev1.Capability{"NET_ADMIN"},
+				},
+			},
+		},
+		steps: []v1.Step{{
+			Image: "some-image",
+			SecurityContext: &corev1.SecurityContext{
+				Capabilities: &corev1.Capabilities{
+					Drop: []corev1.Capability{"ALL"},
+				},
+			},
+		}},
+		expected: []v1.Step{{
+			Image: "some-image",
+			SecurityContext: &corev1.SecurityContext{
+				Capabilities: &corev1.Capabilities{
+					Add:  []corev1.Capability{"NET_ADMIN"},
+					Drop: []corev1.Capability{"ALL"},
+				},
+			},
+		}},
+	}, {
+		name: "capabilities-lists-merged-additively",
+		template: &v1.StepTemplate{
+			SecurityContext: &corev1.SecurityContext{
+				Capabilities: &corev1.Capabilities{
+					Add:  []corev1.Capability{"NET_ADMIN", "SYS_TIME"},
+					Drop: []corev1.Capability{"MKNOD"},
+				},
+			},
+		},
+		steps: []v1.Step{{
+			Image: "some-image",
+			SecurityContext: &corev1.SecurityContext{
+				Capabilities: &corev1.Capabilities{
+					Add:  []corev1.Capability{"SYS_TIME", "NET_RAW"},
+					Drop: []corev1.Capability{"ALL"},
+				},
+			},
+		}},
+		expected: []v1.Step{{
+			Image: "some-image",
+			SecurityContext: &corev1.SecurityContext{
+				Capabilities: &corev1.Capabilities{
+					Add:  []corev1.Capability{"NET_ADMIN", "SYS_TIME", "NET_RAW"},
+					Drop: []corev1.Capability{"MKNOD", "ALL"},
+				},
+			},
+		}},
+	}, {
+		name: "template-requests-and-step-limits-merged",
+		template: &v1.StepTemplate{
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("500m"),
+					corev1.ResourceMemory: resource.MustParse("1Gi"),
+				},
+				Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+			},
+		},
+		steps: []v1.Step{{
+			Image: "some-image",
+			Resources: corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("4Gi")},
+			},
+		}},
+		expected: []v1.Step{{
+			Image: "some-image",
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("500m"),
+					corev1.ResourceMemory: resource.MustParse("1Gi"),
+				},
+				Limits: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("1"),
+					corev1.ResourceMemory: resource.MustParse("4Gi"),
+				},
+			},
+		}},
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
 			result, err := v1.MergeStepsWithStepTemplate(tc.template, tc.steps)
@@ -118,3 +436,587 @@ func TestMergeStepsWithStepTemplate(t *testing.T) {
 		})
 	}
 }
+
+func TestStepTemplateMerger(t *testing.T) {
+	template := &v1.StepTemplate{
+		Command: []string{"/somecmd"},
+		Env: []corev1.EnvVar{{
+			Name:  "KEEP_THIS",
+			Value: "A_VALUE",
+		}},
+	}
+	m, err := v1.NewStepTemplateMerger(template)
+	if err != nil {
+		t.Fatalf("unexpected error creating merger: %v", err)
+	}
+
+	// The same merger should be reusable across independent lists of steps.
+	for _, steps := range [][]v1.Step{{{
+		Image: "some-image",
+	}}, {{
+		Image: "some-other-image",
+		Args:  []string{"foo"},
+	}}} {
+		want, err := v1.MergeStepsWithStepTemplate(template, []v1.Step{*steps[0].DeepCopy()})
+		if err != nil {
+			t.Fatalf("unexpected error merging steps: %v", err)
+		}
+		got, err := m.Merge(steps)
+		if err != nil {
+			t.Fatalf("unexpected error merging steps: %v", err)
+		}
+		if d := cmp.Diff(want, got); d != "" {
+			t.Errorf("merged steps don't match, diff: %s", diff.PrintWantGot(d))
+		}
+	}
+}
+
+func TestStepTemplateMerger_NilTemplate(t *testing.T) {
+	m, err := v1.NewStepTemplateMerger(nil)
+	if err != nil {
+		t.Fatalf("unexpected error creating merger: %v", err)
+	}
+	steps := []v1.Step{{Image: "some-image", OnError: "foo"}}
+	got, err := m.Merge(steps)
+	if err != nil {
+		t.Fatalf("unexpected error merging steps: %v", err)
+	}
+	if d := cmp.Diff(steps, got); d != "" {
+		t.Errorf("merged steps don't match, diff: %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestMergeStepsWithStepTemplate_MutatesSteps(t *testing.T) {
+	template := &v1.StepTemplate{Command: []string{"/somecmd"}}
+	steps := []v1.Step{{Image: "some-image"}}
+
+	if _, err := v1.MergeStepsWithStepTemplate(template, steps); err != nil {
+		t.Fatalf("unexpected error merging steps: %v", err)
+	}
+	want := []v1.Step{{Command: []string{"/somecmd"}, Image: "some-image"}}
+	if d := cmp.Diff(want, steps); d != "" {
+		t.Errorf("expected steps to be merged in place, diff: %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestMergeStepsWithStepTemplate_NoSharedMemory(t *testing.T) {
+	template := &v1.StepTemplate{
+		Command: []string{"/somecmd"},
+		Env: []corev1.EnvVar{{
+			Name:  "TEMPLATE_KEY",
+			Value: "TEMPLATE_VALUE",
+		}},
+		VolumeMounts: []corev1.VolumeMount{{
+			Name:      "data",
+			MountPath: "/data",
+		}},
+	}
+	wantTemplate := template.DeepCopy()
+	stepEnv := []corev1.EnvVar{{
+		Name: "STEP_KEY",
+		ValueFrom: &corev1.EnvVarSource{
+			FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"},
+		},
+	}}
+	wantStepEnv := []corev1.EnvVar{*stepEnv[0].DeepCopy()}
+	steps := []v1.Step{{
+		Image: "some-image",
+		Env:   stepEnv,
+	}, {
+		Image: "some-other-image",
+	}}
+
+	merged, err := v1.MergeStepsWithStepTemplate(template, steps)
+	if err != nil {
+		t.Fatalf("unexpected error merging steps: %v", err)
+	}
+	wantSibling := merged[1].DeepCopy()
+
+	for i := range merged[0].Env {
+		merged[0].Env[i].Value = "CHANGED_VALUE"
+		if merged[0].Env[i].ValueFrom != nil {
+			merged[0].Env[i].ValueFrom.FieldRef.FieldPath = "metadata.namespace"
+		}
+	}
+	merged[0].Command[0] = "/changedcmd"
+	merged[0].VolumeMounts[0].MountPath = "/changed"
+
+	if d := cmp.Diff(wantTemplate, template); d != "" {
+		t.Errorf("expected template to be unchanged, diff: %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff(wantStepEnv, stepEnv); d != "" {
+		t.Errorf("expected original step env to be unchanged, diff: %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff(wantSibling, &merged[1]); d != "" {
+		t.Errorf("expected sibling step to be unchanged, diff: %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestMergedSteps(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		template *v1.StepTemplate
+	}{{
+		name:     "nil-template",
+		template: nil,
+	}, {
+		name: "template",
+		template: &v1.StepTemplate{
+			Command: []string{"/somecmd"},
+			Env: []corev1.EnvVar{{
+				Name:  "KEEP_THIS",
+				Value: "A_VALUE",
+			}},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			steps := []v1.Step{{
+				Image: "some-image",
+				Args:  []string{"foo"},
+				Env: []corev1.EnvVar{{
+					Name:  "SOME_KEY",
+					Value: "SOME_VALUE",
+				}},
+			}}
+			original := []v1.Step{*steps[0].DeepCopy()}
+
+			merged, err := v1.MergedSteps(tc.template, steps)
+			if err != nil {
+				t.Fatalf("unexpected error merging steps: %v", err)
+			}
+			merged[0].Image = "changed-image"
+			merged[0].Args[0] = "changed-arg"
+			merged[0].Env[0].Value = "CHANGED_VALUE"
+
+			if d := cmp.Diff(original, steps); d != "" {
+				t.Errorf("expected original steps to be unchanged, diff: %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func newBenchmarkSteps(n int) []v1.Step {
+	steps := make([]v1.Step, n)
+	for i := range steps {
+		steps[i] = v1.Step{
+			Name:  fmt.Sprintf("step-%d", i),
+			Image: "some-image",
+			Env: []corev1.EnvVar{{
+				Name:  "STEP_INDEX",
+				Value: fmt.Sprint(i),
+			}},
+		}
+	}
+	return steps
+}
+
+var benchmarkTemplate = &v1.StepTemplate{
+	Command: []string{"/somecmd"},
+	Env: []corev1.EnvVar{{
+		Name:  "KEEP_THIS",
+		Value: "A_VALUE",
+	}},
+	Resources: corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+	},
+}
+
+func BenchmarkMergeStepsWithStepTemplate(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		if _, err := v1.MergeStepsWithStepTemplate(benchmarkTemplate, newBenchmarkSteps(50)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkStepTemplateMerger(b *testing.B) {
+	m, err := v1.NewStepTemplateMerger(benchmarkTemplate)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := m.Merge(newBenchmarkSteps(50)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestMergeStepTemplates(t *testing.T) {
+	org := &v1.StepTemplate{
+		Image: "org-image",
+		Env: []corev1.EnvVar{{
+			Name: "ORG", Value: "tekton",
+		}, {
+			Name: "LOG_LEVEL", Value: "info",
+		}},
+		VolumeMounts: []corev1.VolumeMount{{
+			Name: "org-certs", MountPath: "/etc/org-certs",
+		}},
+	}
+	team := &v1.StepTemplate{
+		Env: []corev1.EnvVar{{
+			Name: "TEAM", Value: "pipeline",
+		}, {
+			Name: "LOG_LEVEL", Value: "debug",
+		}},
+		VolumeMounts: []corev1.VolumeMount{{
+			Name: "team-cache", MountPath: "/cache",
+		}},
+	}
+	project := &v1.StepTemplate{
+		Image: "project-image",
+		Env: []corev1.EnvVar{{
+			Name: "PROJECT", Value: "dashboard",
+		}},
+		VolumeMounts: []corev1.VolumeMount{{
+			Name: "project-config", MountPath: "/config",
+		}},
+	}
+	original := []*v1.StepTemplate{org.DeepCopy(), team.DeepCopy(), project.DeepCopy()}
+
+	got, err := v1.MergeStepTemplates(org, nil, team, project)
+	if err != nil {
+		t.Fatalf("MergeStepTemplates() returned unexpected error: %v", err)
+	}
+	want := &v1.StepTemplate{
+		Image: "project-image",
+		Env: []corev1.EnvVar{{
+			Name: "PROJECT", Value: "dashboard",
+		}, {
+			Name: "TEAM", Value: "pipeline",
+		}, {
+			Name: "ORG", Value: "tekton",
+		}, {
+			Name: "LOG_LEVEL", Value: "debug",
+		}},
+		VolumeMounts: []corev1.VolumeMount{{
+			Name: "project-config", MountPath: "/config",
+		}, {
+			Name: "team-cache", MountPath: "/cache",
+		}, {
+			Name: "org-certs", MountPath: "/etc/org-certs",
+		}},
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("MergeStepTemplates() %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff(original, []*v1.StepTemplate{org, team, project}); d != "" {
+		t.Errorf("MergeStepTemplates() modified the templates %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestMergeStepTemplates_Nil(t *testing.T) {
+	template := &v1.StepTemplate{Image: "some-image"}
+	for _, tc := range []struct {
+		name      string
+		templates []*v1.StepTemplate
+		want      *v1.StepTemplate
+	}{{
+		name: "no templates",
+	}, {
+		name:      "only nil templates",
+		templates: []*v1.StepTemplate{nil, nil},
+	}, {
+		name:      "a single template",
+		templates: []*v1.StepTemplate{nil, template},
+		want:      template,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := v1.MergeStepTemplates(tc.templates...)
+			if err != nil {
+				t.Fatalf("MergeStepTemplates() returned unexpected error: %v", err)
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("MergeStepTemplates() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestDedupeEnv(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		env  []corev1.EnvVar
+		want []corev1.EnvVar
+	}{{
+		name: "nil env",
+	}, {
+		name: "no duplicates",
+		env:  []corev1.EnvVar{{Name: "B", Value: "1"}, {Name: "A", Value: "2"}},
+		want: []corev1.EnvVar{{Name: "B", Value: "1"}, {Name: "A", Value: "2"}},
+	}, {
+		name: "last occurrence wins",
+		env:  []corev1.EnvVar{{Name: "A", Value: "1"}, {Name: "A", Value: "2"}, {Name: "A", Value: "3"}},
+		want: []corev1.EnvVar{{Name: "A", Value: "3"}},
+	}, {
+		name: "order of first occurrence is kept",
+		env: []corev1.EnvVar{
+			{Name: "C", Value: "1"},
+			{Name: "A", Value: "2"},
+			{Name: "B", Value: "3"},
+			{Name: "C", Value: "4"},
+			{Name: "A", ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"}}},
+		},
+		want: []corev1.EnvVar{
+			{Name: "C", Value: "4"},
+			{Name: "A", ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"}}},
+			{Name: "B", Value: "3"},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			original := append([]corev1.EnvVar(nil), tc.env...)
+			got := v1.DedupeEnv(tc.env)
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("DedupeEnv() %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(original, tc.env); d != "" {
+				t.Errorf("DedupeEnv() modified env %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestMergeSidecarsWithSidecarTemplate(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		template *v1.StepTemplate
+		sidecars []v1.Sidecar
+		expected []v1.Sidecar
+	}{{
+		name:     "nil-template",
+		template: nil,
+		sidecars: []v1.Sidecar{{
+			Image:  "some-image",
+			Script: "echo hello",
+		}},
+		expected: []v1.Sidecar{{
+			Image:  "some-image",
+			Script: "echo hello",
+		}},
+	}, {
+		name: "not-overlapping",
+		template: &v1.StepTemplate{
+			Command: []string{"/somecmd"},
+		},
+		sidecars: []v1.Sidecar{{
+			Name:   "sidecar",
+			Image:  "some-image",
+			Script: "echo hello",
+		}},
+		expected: []v1.Sidecar{{
+			Name:    "sidecar",
+			Command: []string{"/somecmd"},
+			Image:   "some-image",
+			Script:  "echo hello",
+		}},
+	}, {
+		name: "merge-volume-mounts",
+		template: &v1.StepTemplate{
+			VolumeMounts: []corev1.VolumeMount{{
+				Name:      "template-volume",
+				MountPath: "/template",
+			}, {
+				Name:      "shared-volume",
+				MountPath: "/shared",
+			}},
+		},
+		sidecars: []v1.Sidecar{{
+			Image: "some-image",
+			VolumeMounts: []corev1.VolumeMount{{
+				Name:      "sidecar-volume",
+				MountPath: "/sidecar",
+			}, {
+				Name:      "sidecar-shared-volume",
+				MountPath: "/shared",
+			}},
+		}},
+		expected: []v1.Sidecar{{
+			Image: "some-image",
+			VolumeMounts: []corev1.VolumeMount{{
+				Name:      "sidecar-volume",
+				MountPath: "/sidecar",
+			}, {
+				Name:      "template-volume",
+				MountPath: "/template",
+			}, {
+				Name:      "sidecar-shared-volume",
+				MountPath: "/shared",
+			}},
+		}},
+	}, {
+		name: "nil-args-reset-to-empty",
+		template: &v1.StepTemplate{
+			Command: []string{"/somecmd"},
+		},
+		sidecars: []v1.Sidecar{{
+			Image: "some-image",
+			Args:  []string{},
+		}},
+		expected: []v1.Sidecar{{
+			Command: []string{"/somecmd"},
+			Image:   "some-image",
+			Args:    []string{},
+		}},
+	}, {
+		name: "duplicate-sidecar-env-deduped",
+		template: &v1.StepTemplate{
+			Env: []corev1.EnvVar{{
+				Name:  "FOO",
+				Value: "template-first",
+			}, {
+				Name:  "FOO",
+				Value: "template-second",
+			}, {
+				Name:  "BAR",
+				Value: "template",
+			}},
+		},
+		sidecars: []v1.Sidecar{{
+			Image: "some-image",
+			Env: []corev1.EnvVar{{
+				Name:  "FOO",
+				Value: "sidecar",
+			}},
+		}},
+		expected: []v1.Sidecar{{
+			Image: "some-image",
+			Env: []corev1.EnvVar{{
+				Name:  "FOO",
+				Value: "sidecar",
+			}, {
+				Name:  "BAR",
+				Value: "template",
+			}},
+		}},
+	}, {
+		name: "sidecar-array-param-args-keep-template-args",
+		template: &v1.StepTemplate{
+			Args: []string{"--verbose"},
+		},
+		sidecars: []v1.Sidecar{{
+			Image: "some-image",
+			Args:  []string{"$(params.flags[*])"},
+		}},
+		expected: []v1.Sidecar{{
+			Image: "some-image",
+			Args:  []string{"--verbose", "$(params.flags[*])"},
+		}},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := v1.MergeSidecarsWithSidecarTemplate(tc.template, tc.sidecars)
+			if err != nil {
+				t.Errorf("expected no error. Got error %v", err)
+			}
+
+			if d := cmp.Diff(tc.expected, result); d != "" {
+				t.Errorf("merged sidecars don't match, diff: %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestMergeStepsWithSpecs(t *testing.T) {
+	tcs := []struct {
+		name          string
+		steps         []v1.Step
+		stepOverrides []v1.TaskRunStepSpec
+		want          []v1.Step
+	}{{
+		name: "no overrides",
+		steps: []v1.Step{{
+			Name: "foo",
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+			},
+		}},
+		want: []v1.Step{{
+			Name: "foo",
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+			},
+		}},
+	}, {
+		name: "not all steps overridden",
+		steps: []v1.Step{{
+			Name: "foo",
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+			},
+		}, {
+			Name: "bar",
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+			},
+		}},
+		stepOverrides: []v1.TaskRunStepSpec{{
+			Name: "bar",
+			ComputeResources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")},
+			},
+		}},
+		want: []v1.Step{{
+			Name: "foo",
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+			},
+		}, {
+			Name: "bar",
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")},
+			},
+		}},
+	}, {
+		name: "override limit but not request",
+		steps: []v1.Step{{
+			Name: "foo",
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+				Limits: corev1.ResourceList{
+					corev1.ResourceMemory: resource.MustParse("2Gi"),
+					corev1.ResourceCPU:    resource.MustParse("1"),
+				},
+			},
+		}},
+		stepOverrides: []v1.TaskRunStepSpec{{
+			Name: "foo",
+			ComputeResources: corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("3Gi")},
+			},
+		}},
+		want: []v1.Step{{
+			Name: "foo",
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+				Limits: corev1.ResourceList{
+					corev1.ResourceMemory: resource.MustParse("3Gi"),
+					corev1.ResourceCPU:    resource.MustParse("1"),
+				},
+			},
+		}},
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			steps, err := v1.MergeStepsWithSpecs(tc.steps, tc.stepOverrides)
+			if err != nil {
+				t.Errorf("unexpected error merging steps with overrides: %s", err)
+			}
+			if d := cmp.Diff(tc.want, steps); d != "" {
+				t.Errorf("merged steps don't match, diff: %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestMergeStepsWithSpecs_UnknownStep(t *testing.T) {
+	steps := []v1.Step{{Name: "foo"}}
+	stepOverrides := []v1.TaskRunStepSpec{{
+		Name: "bar",
+		ComputeResources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+		},
+	}}
+	if _, err := v1.MergeStepsWithSpecs(steps, stepOverrides); err == nil {
+		t.Error("expected error merging an override for a step that doesn't exist, got nil")
+	}
+}
@@ -21,6 +21,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 
 	resource "github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1"
@@ -58,6 +60,70 @@ type ParamSpec struct {
 	// parameter.
 	// +optional
 	Default *ArrayOrString `json:"default,omitempty"`
+	// Enum declares the values that a string parameter is allowed to take. If set, both
+	// the value supplied for the parameter and its Default must be one of them.
+	// +optional
+	// +listType=atomic
+	Enum []string `json:"enum,omitempty"`
+}
+
+// AllowsValue returns true if the ParamSpec declares no Enum or if value is one of its values.
+func (pp *ParamSpec) AllowsValue(value string) bool {
+	if len(pp.Enum) == 0 {
+		return true
+	}
+	for _, e := range pp.Enum {
+		if e == value {
+			return true
+		}
+	}
+	return false
+}
+
+// JSONSchema returns a JSON Schema fragment describing the values that the ParamSpec accepts. Object
+// params are described by their Properties, and every property that the param's Default doesn't provide
+// a value for is required. The type is inferred the same way as by SetDefaults if it isn't set.
+func (pp *ParamSpec) JSONSchema() (map[string]interface{}, error) {
+	p := pp.DeepCopy()
+	p.SetDefaults(context.Background())
+
+	schema := map[string]interface{}{}
+	if p.Description != "" {
+		schema["description"] = p.Description
+	}
+	switch p.Type {
+	case ParamTypeString:
+		schema["type"] = "string"
+		if len(p.Enum) > 0 {
+			schema["enum"] = append([]string{}, p.Enum...)
+		}
+	case ParamTypeArray:
+		schema["type"] = "array"
+		schema["items"] = map[string]interface{}{"type": "string"}
+	case ParamTypeObject:
+		properties := make(map[string]interface{}, len(p.Properties))
+		required := []string{}
+		for key, property := range p.Properties {
+			if property.Type != ParamTypeString {
+				return nil, fmt.Errorf("property %q of param %q has unsupported type %q", key, p.Name, property.Type)
+			}
+			properties[key] = map[string]interface{}{"type": "string"}
+			if p.Default == nil {
+				required = append(required, key)
+			} else if _, ok := p.Default.ObjectVal[key]; !ok {
+				required = append(required, key)
+			}
+		}
+		sort.Strings(required)
+		schema["type"] = "object"
+		schema["properties"] = properties
+		if len(required) > 0 {
+			schema["required"] = required
+		}
+	default:
+		return nil, fmt.Errorf("param %q has unsupported type %q", p.Name, p.Type)
+	}
+	return schema, nil
 }
 
 // PropertySpec defines the struct for object keys
@@ -175,7 +241,8 @@ func (arrayOrString *ArrayOrString) UnmarshalJSON(value []byte) error {
 		}
 	}
 
-	// By default we unmarshal to string
+	// By default we unmarshal to string. Bare JSON numbers and booleans can't be unmarshalled
+	// to a string, so they keep their literal representation, e.g. `count: 3` becomes "3".
 	arrayOrString.Type = ParamTypeString
 	if err := json.Unmarshal(value, &arrayOrString.StringVal); err == nil {
 		return nil
@@ -199,6 +266,125 @@ func (arrayOrString ArrayOrString) MarshalJSON() ([]byte, error) {
 	}
 }
 
+// ToString returns the canonical string form of the ArrayOrString: the string value
+// itself for string params, and the JSON encoding of the value for array and object params.
+func (arrayOrString ArrayOrString) ToString() string {
+	switch arrayOrString.Type {
+	case ParamTypeArray:
+		val := arrayOrString.ArrayVal
+		if val == nil {
+			val = []string{}
+		}
+		// Marshalling a []string can't fail.
+		b, _ := json.Marshal(val)
+		return string(b)
+	case ParamTypeObject:
+		val := arrayOrString.ObjectVal
+		if val == nil {
+			val = map[string]string{}
+		}
+		// Marshalling a map[string]string can't fail, and its keys are sorted.
+		b, _ := json.Marshal(val)
+		return string(b)
+	default:
+		return arrayOrString.StringVal
+	}
+}
+
+// Equal returns true if other holds the same type and value as the ArrayOrString. Unlike
+// reflect.DeepEqual, nil and empty arrays or objects are equal, and only the value of the
+// type being held is compared. An unset type is treated as a string.
+func (arrayOrString ArrayOrString) Equal(other ArrayOrString) bool {
+	if arrayOrString.paramType() != other.paramType() {
+		return false
+	}
+	switch arrayOrString.paramType() {
+	case ParamTypeArray:
+		if len(arrayOrString.ArrayVal) != len(other.ArrayVal) {
+			return false
+		}
+		for i, v := range arrayOrString.ArrayVal {
+			if other.ArrayVal[i] != v {
+				return false
+			}
+		}
+		return true
+	case ParamTypeObject:
+		if len(arrayOrString.ObjectVal) != len(other.ObjectVal) {
+			return false
+		}
+		for k, v := range arrayOrString.ObjectVal {
+			if ov, ok := other.ObjectVal[k]; !ok || ov != v {
+				return false
+			}
+		}
+		return true
+	default:
+		return arrayOrString.StringVal == other.StringVal
+	}
+}
+
+// IndexReplacements returns the string replacements for references to the individual values of the
+// param called paramName holding the ArrayOrString: `params.<name>[<index>]` for each element of an array,
+// `params.<name>.<key>` for each member of an object, and `params.<name>` for a string.
+func (arrayOrString ArrayOrString) IndexReplacements(paramName string) map[string]string {
+	replacements := map[string]string{}
+	switch arrayOrString.paramType() {
+	case ParamTypeArray:
+		for i, v := range arrayOrString.ArrayVal {
+			replacements[fmt.Sprintf("%s.%s[%d]", ParamsPrefix, paramName, i)] = v
+		}
+	case ParamTypeObject:
+		for k, v := range arrayOrString.ObjectVal {
+			replacements[fmt.Sprintf("%s.%s.%s", ParamsPrefix, paramName, k)] = v
+		}
+	default:
+		replacements[fmt.Sprintf("%s.%s", ParamsPrefix, paramName)] = arrayOrString.StringVal
+	}
+	return replacements
+}
+
+// AsInt returns the value of a string ArrayOrString parsed as an integer.
+func (arrayOrString ArrayOrString) AsInt() (int, error) {
+	if t := arrayOrString.paramType(); t != ParamTypeString {
+		return 0, fmt.Errorf("cannot convert a value of type %s to an integer", t)
+	}
+	i, err := strconv.Atoi(arrayOrString.StringVal)
+	if err != nil {
+		return 0, fmt.Errorf("value %q is not an integer", arrayOrString.StringVal)
+	}
+	return i, nil
+}
+
+// AsBool returns the value of a string ArrayOrString parsed as a boolean, with the values accepted by
+// strconv.ParseBool.
+func (arrayOrString ArrayOrString) AsBool() (bool, error) {
+	if t := arrayOrString.paramType(); t != ParamTypeString {
+		return false, fmt.Errorf("cannot convert a value of type %s to a boolean", t)
+	}
+	b, err := strconv.ParseBool(arrayOrString.StringVal)
+	if err != nil {
+		return false, fmt.Errorf("value %q is not a boolean", arrayOrString.StringVal)
+	}
+	return b, nil
+}
+
+// AsStringSlice returns the elements of an array ArrayOrString.
+func (arrayOrString ArrayOrString) AsStringSlice() ([]string, error) {
+	if t := arrayOrString.paramType(); t != ParamTypeArray {
+		return nil, fmt.Errorf("cannot convert a value of type %s to a string slice", t)
+	}
+	return arrayOrString.ArrayVal, nil
+}
+
+// paramType returns the type of the ArrayOrString, defaulting to a string when it's unset.
+func (arrayOrString ArrayOrString) paramType() ParamType {
+	if arrayOrString.Type == "" {
+		return ParamTypeString
+	}
+	return arrayOrString.Type
+}
+
 // ApplyReplacements applyes replacements for ArrayOrString type
 func (arrayOrString *ArrayOrString) ApplyReplacements(stringReplacements map[string]string, arrayReplacements map[string][]string, objectReplacements map[string]map[string]string) {
 	switch arrayOrString.Type {
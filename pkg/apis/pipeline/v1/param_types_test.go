@@ -145,6 +145,39 @@ func TestParamSpec_SetDefaults(t *testing.T) {
 				ObjectVal: map[string]string{"url": "test", "path": "test"},
 			},
 		},
+	}, {
+		name: "inferred type from default value type",
+		before: &v1.ParamSpec{
+			Name: "parametername",
+			Default: &v1.ArrayOrString{
+				Type:     v1.ParamTypeArray,
+				ArrayVal: []string{"array"},
+			},
+		},
+		defaultsApplied: &v1.ParamSpec{
+			Name: "parametername",
+			Type: v1.ParamTypeArray,
+			Default: &v1.ArrayOrString{
+				Type:     v1.ParamTypeArray,
+				ArrayVal: []string{"array"},
+			},
+		},
+	}, {
+		name: "provided type takes precedence over default value",
+		before: &v1.ParamSpec{
+			Name: "parametername",
+			Type: v1.ParamTypeString,
+			Default: &v1.ArrayOrString{
+				ArrayVal: []string{"array"},
+			},
+		},
+		defaultsApplied: &v1.ParamSpec{
+			Name: "parametername",
+			Type: v1.ParamTypeString,
+			Default: &v1.ArrayOrString{
+				ArrayVal: []string{"array"},
+			},
+		},
 	}}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
@@ -157,6 +190,108 @@ func TestParamSpec_SetDefaults(t *testing.T) {
 	}
 }
 
+func TestParamSpec_JSONSchema(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		spec v1.ParamSpec
+		want map[string]interface{}
+	}{{
+		name: "string param",
+		spec: v1.ParamSpec{Name: "url", Description: "the repository url"},
+		want: map[string]interface{}{
+			"type":        "string",
+			"description": "the repository url",
+		},
+	}, {
+		name: "string param with enum",
+		spec: v1.ParamSpec{Name: "mode", Type: v1.ParamTypeString, Enum: []string{"fast", "slow"}},
+		want: map[string]interface{}{
+			"type": "string",
+			"enum": []string{"fast", "slow"},
+		},
+	}, {
+		name: "array param",
+		spec: v1.ParamSpec{Name: "flags", Type: v1.ParamTypeArray},
+		want: map[string]interface{}{
+			"type":  "array",
+			"items": map[string]interface{}{"type": "string"},
+		},
+	}, {
+		name: "object param with two properties",
+		spec: v1.ParamSpec{
+			Name: "gitrepo",
+			Properties: map[string]v1.PropertySpec{
+				"url":    {Type: v1.ParamTypeString},
+				"commit": {},
+			},
+		},
+		want: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"url":    map[string]interface{}{"type": "string"},
+				"commit": map[string]interface{}{"type": "string"},
+			},
+			"required": []string{"commit", "url"},
+		},
+	}, {
+		name: "object param with defaulted property",
+		spec: v1.ParamSpec{
+			Name: "gitrepo",
+			Type: v1.ParamTypeObject,
+			Properties: map[string]v1.PropertySpec{
+				"url":    {Type: v1.ParamTypeString},
+				"commit": {Type: v1.ParamTypeString},
+			},
+			Default: v1.NewObject(map[string]string{"commit": "main"}),
+		},
+		want: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"url":    map[string]interface{}{"type": "string"},
+				"commit": map[string]interface{}{"type": "string"},
+			},
+			"required": []string{"url"},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			original := tc.spec.DeepCopy()
+			got, err := tc.spec.JSONSchema()
+			if err != nil {
+				t.Fatalf("JSONSchema() returned unexpected error: %v", err)
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("JSONSchema() %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(original, &tc.spec); d != "" {
+				t.Errorf("JSONSchema() modified the ParamSpec %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestParamSpec_JSONSchema_Error(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		spec v1.ParamSpec
+	}{{
+		name: "unsupported param type",
+		spec: v1.ParamSpec{Name: "count", Type: "integer"},
+	}, {
+		name: "unsupported property type",
+		spec: v1.ParamSpec{
+			Name:       "gitrepo",
+			Type:       v1.ParamTypeObject,
+			Properties: map[string]v1.PropertySpec{"depth": {Type: v1.ParamTypeArray}},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := tc.spec.JSONSchema(); err == nil {
+				t.Error("JSONSchema() did not return error for unsupported type")
+			}
+		})
+	}
+}
+
 func TestArrayOrString_ApplyReplacements(t *testing.T) {
 	type args struct {
 		input              *v1.ArrayOrString
@@ -276,6 +411,34 @@ func TestArrayOrString_ApplyReplacements(t *testing.T) {
 			"key1": "foo",
 			"key2": "bar",
 		}),
+	}, {
+		name: "param replacement on object property",
+		args: args{
+			input: v1.NewObject(map[string]string{
+				"url":    "https://$(params.sub).example.com",
+				"commit": "$(params.sub)",
+			}),
+			stringReplacements: map[string]string{"params.sub": "abc"},
+		},
+		expectedOutput: v1.NewObject(map[string]string{
+			"url":    "https://abc.example.com",
+			"commit": "abc",
+		}),
+	}, {
+		name: "array param references on object properties",
+		args: args{
+			input: v1.NewObject(map[string]string{
+				"first": "$(params.myarray[0])",
+				"all":   "$(params.myarray[*])",
+			}),
+			stringReplacements: map[string]string{"params.myarray[0]": "a", "params.myarray[1]": "b"},
+			arrayReplacements:  map[string][]string{"params.myarray": {"a", "b"}},
+		},
+		// object property values are strings, so only array elements can be substituted into them
+		expectedOutput: v1.NewObject(map[string]string{
+			"first": "a",
+			"all":   "$(params.myarray[*])",
+		}),
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -363,6 +526,9 @@ func TestArrayOrString_UnmarshalJSON_Directly(t *testing.T) {
 	}{
 		{desc: "empty value", input: ``, expected: *v1.NewArrayOrString("")},
 		{desc: "int value", input: `1`, expected: *v1.NewArrayOrString("1")},
+		{desc: "float value", input: `3.14`, expected: *v1.NewArrayOrString("3.14")},
+		{desc: "bool value", input: `true`, expected: *v1.NewArrayOrString("true")},
+		{desc: "null value", input: `null`, expected: *v1.NewArrayOrString("")},
 		{desc: "int array", input: `[1,2,3]`, expected: *v1.NewArrayOrString("[1,2,3]")},
 		{desc: "nested array", input: `[1,\"2\",3]`, expected: *v1.NewArrayOrString(`[1,\"2\",3]`)},
 		{desc: "string value", input: `hello`, expected: *v1.NewArrayOrString("hello")},
@@ -421,6 +587,276 @@ func TestArrayOrString_MarshalJSON(t *testing.T) {
 	}
 }
 
+func TestArrayOrString_ToString(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		input v1.ArrayOrString
+		want  string
+	}{{
+		name:  "zero value",
+		input: v1.ArrayOrString{},
+		want:  "",
+	}, {
+		name:  "string",
+		input: *v1.NewArrayOrString("foo"),
+		want:  "foo",
+	}, {
+		name:  "array",
+		input: *v1.NewArrayOrString("foo", "bar"),
+		want:  `["foo","bar"]`,
+	}, {
+		name:  "empty array",
+		input: v1.ArrayOrString{Type: v1.ParamTypeArray, ArrayVal: []string{}},
+		want:  "[]",
+	}, {
+		name:  "nil array",
+		input: v1.ArrayOrString{Type: v1.ParamTypeArray},
+		want:  "[]",
+	}, {
+		name:  "object",
+		input: *v1.NewObject(map[string]string{"key2": "var2", "key1": "var1"}),
+		want:  `{"key1":"var1","key2":"var2"}`,
+	}, {
+		name:  "object with nested json value",
+		input: *v1.NewObject(map[string]string{"key": `{"nested":"value"}`}),
+		want:  `{"key":"{\"nested\":\"value\"}"}`,
+	}, {
+		name:  "nil object",
+		input: v1.ArrayOrString{Type: v1.ParamTypeObject},
+		want:  "{}",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if d := cmp.Diff(tc.want, tc.input.ToString()); d != "" {
+				t.Errorf(diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestArrayOrString_Equal(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		a, b v1.ArrayOrString
+		want bool
+	}{{
+		name: "same string",
+		a:    *v1.NewArrayOrString("1"),
+		b:    *v1.NewArrayOrString("1"),
+		want: true,
+	}, {
+		name: "different strings",
+		a:    *v1.NewArrayOrString("1"),
+		b:    *v1.NewArrayOrString("2"),
+	}, {
+		name: "unset type is a string",
+		a:    v1.ArrayOrString{StringVal: "1"},
+		b:    *v1.NewArrayOrString("1"),
+		want: true,
+	}, {
+		name: "string vs array",
+		a:    *v1.NewArrayOrString("1"),
+		b:    v1.ArrayOrString{Type: v1.ParamTypeArray, ArrayVal: []string{"1"}},
+	}, {
+		name: "values of other types are ignored",
+		a:    v1.ArrayOrString{Type: v1.ParamTypeString, StringVal: "1", ArrayVal: []string{"2"}},
+		b:    *v1.NewArrayOrString("1"),
+		want: true,
+	}, {
+		name: "same array",
+		a:    *v1.NewArrayOrString("1", "2"),
+		b:    *v1.NewArrayOrString("1", "2"),
+		want: true,
+	}, {
+		name: "array order matters",
+		a:    *v1.NewArrayOrString("1", "2"),
+		b:    *v1.NewArrayOrString("2", "1"),
+	}, {
+		name: "nil vs empty array",
+		a:    v1.ArrayOrString{Type: v1.ParamTypeArray},
+		b:    v1.ArrayOrString{Type: v1.ParamTypeArray, ArrayVal: []string{}},
+		want: true,
+	}, {
+		name: "object member order doesn't matter",
+		a:    v1.ArrayOrString{Type: v1.ParamTypeObject, ObjectVal: map[string]string{"key1": "val1", "key2": "val2"}},
+		b:    v1.ArrayOrString{Type: v1.ParamTypeObject, ObjectVal: map[string]string{"key2": "val2", "key1": "val1"}},
+		want: true,
+	}, {
+		name: "different object values",
+		a:    *v1.NewObject(map[string]string{"key1": "val1"}),
+		b:    *v1.NewObject(map[string]string{"key1": "val2"}),
+	}, {
+		name: "different object keys",
+		a:    *v1.NewObject(map[string]string{"key1": "val1"}),
+		b:    *v1.NewObject(map[string]string{"key2": "val1"}),
+	}, {
+		name: "nil vs empty object",
+		a:    v1.ArrayOrString{Type: v1.ParamTypeObject},
+		b:    *v1.NewObject(map[string]string{}),
+		want: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.a.Equal(tc.b); got != tc.want {
+				t.Errorf("Equal() = %t, want %t", got, tc.want)
+			}
+			if got := tc.b.Equal(tc.a); got != tc.want {
+				t.Errorf("Equal() with swapped operands = %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestArrayOrString_IndexReplacements(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		value v1.ArrayOrString
+		want  map[string]string
+	}{{
+		name:  "string",
+		value: *v1.NewArrayOrString("foo"),
+		want:  map[string]string{"params.p": "foo"},
+	}, {
+		name:  "array with three elements",
+		value: *v1.NewArrayOrString("v0", "v1", "v2"),
+		want: map[string]string{
+			"params.p[0]": "v0",
+			"params.p[1]": "v1",
+			"params.p[2]": "v2",
+		},
+	}, {
+		name:  "empty array",
+		value: v1.ArrayOrString{Type: v1.ParamTypeArray},
+		want:  map[string]string{},
+	}, {
+		name:  "object with two keys",
+		value: *v1.NewObject(map[string]string{"url": "https://example.com", "commit": "abc"}),
+		want: map[string]string{
+			"params.p.url":    "https://example.com",
+			"params.p.commit": "abc",
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if d := cmp.Diff(tc.want, tc.value.IndexReplacements("p")); d != "" {
+				t.Errorf("IndexReplacements() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestArrayOrString_AsInt(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		value   v1.ArrayOrString
+		want    int
+		wantErr string
+	}{{
+		name:  "integer",
+		value: *v1.NewArrayOrString("42"),
+		want:  42,
+	}, {
+		name:  "negative integer without type",
+		value: v1.ArrayOrString{StringVal: "-3"},
+		want:  -3,
+	}, {
+		name:    "not an integer",
+		value:   *v1.NewArrayOrString("4.2"),
+		wantErr: `value "4.2" is not an integer`,
+	}, {
+		name:    "array",
+		value:   *v1.NewArrayOrString("1", "2"),
+		wantErr: "cannot convert a value of type array to an integer",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.value.AsInt()
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("AsInt() = %d, %v, want error %q", got, err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("AsInt() = %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("AsInt() = %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestArrayOrString_AsBool(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		value   v1.ArrayOrString
+		want    bool
+		wantErr string
+	}{{
+		name:  "true",
+		value: *v1.NewArrayOrString("true"),
+		want:  true,
+	}, {
+		name:  "false",
+		value: *v1.NewArrayOrString("False"),
+		want:  false,
+	}, {
+		name:    "not a boolean",
+		value:   *v1.NewArrayOrString("yes"),
+		wantErr: `value "yes" is not a boolean`,
+	}, {
+		name:    "object",
+		value:   *v1.NewObject(map[string]string{"enabled": "true"}),
+		wantErr: "cannot convert a value of type object to a boolean",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.value.AsBool()
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("AsBool() = %t, %v, want error %q", got, err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("AsBool() = %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("AsBool() = %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestArrayOrString_AsStringSlice(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		value   v1.ArrayOrString
+		want    []string
+		wantErr string
+	}{{
+		name:  "array",
+		value: *v1.NewArrayOrString("a", "b"),
+		want:  []string{"a", "b"},
+	}, {
+		name:    "string",
+		value:   *v1.NewArrayOrString("a"),
+		wantErr: "cannot convert a value of type string to a string slice",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.value.AsStringSlice()
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("AsStringSlice() = %v, %v, want error %q", got, err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("AsStringSlice() = %v", err)
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("AsStringSlice() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestArrayReference(t *testing.T) {
 	tests := []struct {
 		name, p, expectedResult string
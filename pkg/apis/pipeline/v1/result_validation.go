@@ -35,7 +35,12 @@ func (tr TaskResult) Validate(ctx context.Context) (errs *apis.FieldError) {
 	}
 	// Array and Object are alpha features
 	if tr.Type == ResultsTypeArray || tr.Type == ResultsTypeObject {
-		return errs.Also(version.ValidateEnabledAPIFields(ctx, "results type", config.AlphaAPIFields))
+		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "results type", config.AlphaAPIFields))
+		// Object results must declare their keys so that they can be validated
+		if tr.Type == ResultsTypeObject && len(tr.Properties) == 0 {
+			errs = errs.Also(apis.ErrMissingField("properties"))
+		}
+		return errs
 	}
 
 	// Resources created before the result. Type was introduced may not have Type set
@@ -62,8 +62,11 @@ func TestResultsValidate(t *testing.T) {
 		name: "valid result type object",
 		Result: v1.TaskResult{
 			Name:        "MY-RESULT",
-			Type:        "array",
+			Type:        "object",
 			Description: "my great result",
+			Properties: map[string]v1.PropertySpec{
+				"url": {Type: "string"},
+			},
 		},
 
 		apiFields: "alpha",
@@ -130,11 +133,26 @@ func TestResultsValidateError(t *testing.T) {
 			Name:        "MY-RESULT",
 			Type:        "object",
 			Description: "my great result",
+			Properties: map[string]v1.PropertySpec{
+				"url": {Type: "string"},
+			},
 		},
 		apiFields: "stable",
 		expectedError: apis.FieldError{
 			Message: "results type requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"stable\"",
 		},
+	}, {
+		name: "invalid object result type without properties",
+		Result: v1.TaskResult{
+			Name:        "MY-RESULT",
+			Type:        "object",
+			Description: "my great result",
+		},
+		apiFields: "alpha",
+		expectedError: apis.FieldError{
+			Message: "missing field(s)",
+			Paths:   []string{"properties"},
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
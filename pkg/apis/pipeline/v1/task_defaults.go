@@ -18,7 +18,9 @@ package v1
 
 import (
 	"context"
+	"fmt"
 
+	"k8s.io/apimachinery/pkg/util/sets"
 	"knative.dev/pkg/apis"
 )
 
@@ -37,4 +39,25 @@ func (ts *TaskSpec) SetDefaults(ctx context.Context) {
 	for i := range ts.Results {
 		ts.Results[i].SetDefaults(ctx)
 	}
+	ts.defaultSidecarNames()
+}
+
+// defaultSidecarNames names the unnamed Sidecars "sidecar-<index>", adding a further "-<n>" suffix
+// if that's the name of another Sidecar, so that every Sidecar has a unique name.
+func (ts *TaskSpec) defaultSidecarNames() {
+	names := sets.NewString()
+	for _, s := range ts.Sidecars {
+		names.Insert(s.Name)
+	}
+	for i := range ts.Sidecars {
+		if ts.Sidecars[i].Name != "" {
+			continue
+		}
+		name := fmt.Sprintf("sidecar-%d", i)
+		for n := 1; names.Has(name); n++ {
+			name = fmt.Sprintf("sidecar-%d-%d", i, n)
+		}
+		ts.Sidecars[i].Name = name
+		names.Insert(name)
+	}
 }
@@ -0,0 +1,77 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+func TestTaskSpecSetDefaults_SidecarNames(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		sidecars []v1.Sidecar
+		want     []v1.Sidecar
+	}{{
+		name: "unnamed sidecars",
+		sidecars: []v1.Sidecar{{
+			Image: "registry",
+		}, {
+			Image: "database",
+		}},
+		want: []v1.Sidecar{{
+			Name:  "sidecar-0",
+			Image: "registry",
+		}, {
+			Name:  "sidecar-1",
+			Image: "database",
+		}},
+	}, {
+		name: "mixed named and unnamed sidecars",
+		sidecars: []v1.Sidecar{{
+			Image: "registry",
+		}, {
+			Name:  "sidecar-0",
+			Image: "database",
+		}, {
+			Name:  "cache",
+			Image: "redis",
+		}},
+		want: []v1.Sidecar{{
+			Name:  "sidecar-0-1",
+			Image: "registry",
+		}, {
+			Name:  "sidecar-0",
+			Image: "database",
+		}, {
+			Name:  "cache",
+			Image: "redis",
+		}},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ts := v1.TaskSpec{Sidecars: tc.sidecars}
+			ts.SetDefaults(context.Background())
+			if d := cmp.Diff(tc.want, ts.Sidecars); d != "" {
+				t.Errorf("TaskSpec.SetDefaults() sidecars %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
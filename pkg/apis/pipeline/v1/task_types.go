@@ -17,10 +17,19 @@ limitations under the License.
 package v1
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/substitution"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"knative.dev/pkg/kmeta"
 )
 
@@ -95,6 +104,425 @@ type TaskSpec struct {
 	Results []TaskResult `json:"results,omitempty"`
 }
 
+// ResultNames returns the names of the results declared by the TaskSpec.
+func (ts *TaskSpec) ResultNames() sets.String {
+	names := sets.NewString()
+	for _, r := range ts.Results {
+		names.Insert(r.Name)
+	}
+	return names
+}
+
+// ResultsByName returns the results declared by the TaskSpec, keyed by their names.
+func (ts *TaskSpec) ResultsByName() map[string]TaskResult {
+	results := make(map[string]TaskResult, len(ts.Results))
+	for _, r := range ts.Results {
+		results[r.Name] = r
+	}
+	return results
+}
+
+// StepByName returns a pointer to the TaskSpec's Step with the given name and its index, or nil and -1
+// if there's no such Step. Unnamed Steps are never returned.
+func (ts *TaskSpec) StepByName(name string) (*Step, int) {
+	if name == "" {
+		return nil, -1
+	}
+	for i := range ts.Steps {
+		if ts.Steps[i].Name == name {
+			return &ts.Steps[i], i
+		}
+	}
+	return nil, -1
+}
+
+// NativeSidecarSteps returns the TaskSpec's Steps that are declared as native Kubernetes sidecars.
+func (ts *TaskSpec) NativeSidecarSteps() []Step {
+	var steps []Step
+	for _, s := range ts.Steps {
+		if s.IsNativeSidecar() {
+			steps = append(steps, s)
+		}
+	}
+	return steps
+}
+
+// Images returns the sorted, de-duplicated images of the TaskSpec's Steps and Sidecars. Steps that don't
+// set an image get the StepTemplate's, as they would once merged with it. Empty images aren't included.
+func (ts *TaskSpec) Images() []string {
+	images := sets.NewString()
+	for _, s := range ts.Steps {
+		image := s.Image
+		if image == "" && ts.StepTemplate != nil {
+			image = ts.StepTemplate.Image
+		}
+		images.Insert(image)
+	}
+	for _, s := range ts.Sidecars {
+		images.Insert(s.Image)
+	}
+	images.Delete("")
+	return images.List()
+}
+
+// PrivilegedSteps returns the container names, see Step.ContainerName, of the TaskSpec's Steps that run in
+// privileged mode once merged with the StepTemplate, in declaration order, so that unnamed Steps can be told
+// apart too. A Step that doesn't set SecurityContext.Privileged gets the StepTemplate's.
+func (ts *TaskSpec) PrivilegedSteps() []string {
+	var names []string
+	for i, s := range ts.Steps {
+		privileged := s.IsPrivileged()
+		if (s.SecurityContext == nil || s.SecurityContext.Privileged == nil) && ts.StepTemplate != nil {
+			template := Step{SecurityContext: ts.StepTemplate.SecurityContext}
+			privileged = template.IsPrivileged()
+		}
+		if privileged {
+			names = append(names, s.ContainerName(i))
+		}
+	}
+	return names
+}
+
+// EnvNames returns the names of the env vars set in the containers of the TaskSpec's Steps, once merged
+// with the StepTemplate, and Sidecars. Env vars set through EnvFrom aren't known until run time and aren't
+// included.
+func (ts *TaskSpec) EnvNames() sets.String {
+	names := sets.NewString()
+	if ts.StepTemplate != nil {
+		for _, e := range ts.StepTemplate.Env {
+			names.Insert(e.Name)
+		}
+	}
+	for _, s := range ts.Steps {
+		for _, e := range s.Env {
+			names.Insert(e.Name)
+		}
+	}
+	for _, s := range ts.Sidecars {
+		for _, e := range s.Env {
+			names.Insert(e.Name)
+		}
+	}
+	return names
+}
+
+// ReferencedParams returns the names of the params referenced in the TaskSpec's Steps, StepTemplate,
+// Sidecars, Workspaces and Results. References to array elements and object keys, e.g. $(params.foo[0])
+// or $(params.foo.key), are references to the param itself. An error is returned if a reference is
+// malformed, see substitution.ExtractVariableNames.
+func (ts *TaskSpec) ReferencedParams() (sets.String, error) {
+	var values []string
+	for _, s := range ts.Steps {
+		values = append(values, containerStrings(s.ToK8sContainer())...)
+		values = append(values, s.Script)
+		for _, we := range s.When {
+			values = append(values, we.Input)
+			values = append(values, we.Values...)
+		}
+	}
+	if ts.StepTemplate != nil {
+		values = append(values, containerStrings(ts.StepTemplate.ToK8sContainer())...)
+	}
+	for _, s := range ts.Sidecars {
+		values = append(values, containerStrings(s.ToK8sContainer())...)
+		values = append(values, s.Script)
+	}
+	for _, w := range ts.Workspaces {
+		values = append(values, w.MountPath, w.Description)
+	}
+	for _, r := range ts.Results {
+		values = append(values, r.Description)
+	}
+
+	names := sets.NewString()
+	for _, v := range values {
+		vars, err := substitution.ExtractVariableNames(v, ParamsPrefix)
+		if err != nil {
+			return nil, err
+		}
+		names.Insert(vars...)
+	}
+	return names, nil
+}
+
+// ConditionalWorkspaces returns the names of the TaskSpec's optional workspaces that are only used by Steps
+// with when expressions, which makes them needed only when those Steps run, e.g. when a param enables them.
+// A workspace is used by a Step or Sidecar that lists it in its Workspaces or references one of its
+// variables, such as $(workspaces.cache.path). Optional workspaces that aren't used by any Step aren't
+// returned, and neither are those used by the StepTemplate, a Sidecar or a Step without when expressions.
+// An error is returned if a workspace variable is malformed, see substitution.ExtractVariableNames.
+func (ts *TaskSpec) ConditionalWorkspaces() (sets.String, error) {
+	var err error
+	used := func(values []string, usages []WorkspaceUsage) sets.String {
+		names := sets.NewString()
+		for _, v := range values {
+			vars, e := substitution.ExtractVariableNames(v, "workspaces")
+			if e != nil && err == nil {
+				err = e
+			}
+			names.Insert(vars...)
+		}
+		for _, u := range usages {
+			names.Insert(u.Name)
+		}
+		return names
+	}
+
+	guarded, unguarded := sets.NewString(), sets.NewString()
+	for _, s := range ts.Steps {
+		names := used(append(containerStrings(s.ToK8sContainer()), s.Script), s.Workspaces)
+		if len(s.When) != 0 {
+			guarded = guarded.Union(names)
+		} else {
+			unguarded = unguarded.Union(names)
+		}
+	}
+	if ts.StepTemplate != nil {
+		unguarded = unguarded.Union(used(containerStrings(ts.StepTemplate.ToK8sContainer()), nil))
+	}
+	for _, s := range ts.Sidecars {
+		unguarded = unguarded.Union(used(append(containerStrings(s.ToK8sContainer()), s.Script), s.Workspaces))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	conditional := sets.NewString()
+	for _, w := range ts.Workspaces {
+		if w.Optional && guarded.Has(w.Name) && !unguarded.Has(w.Name) {
+			conditional.Insert(w.Name)
+		}
+	}
+	return conditional, nil
+}
+
+// containerStrings returns the fields of c that variables can be substituted into.
+func containerStrings(c *corev1.Container) []string {
+	values := []string{c.Name, c.Image, c.WorkingDir}
+	values = append(values, c.Command...)
+	values = append(values, c.Args...)
+	for _, e := range c.Env {
+		values = append(values, e.Value)
+	}
+	for _, v := range c.VolumeMounts {
+		values = append(values, v.Name, v.MountPath, v.SubPath)
+	}
+	return values
+}
+
+// EffectiveResourceRequests returns the resources that a run of the TaskSpec requests. Steps run one
+// after the other, so for each resource it's the largest request of any Step, once merged with the
+// StepTemplate, plus the requests of all the Sidecars, which run alongside the Steps.
+func (ts *TaskSpec) EffectiveResourceRequests() corev1.ResourceList {
+	requests := corev1.ResourceList{}
+	for _, s := range ts.Steps {
+		stepRequests := corev1.ResourceList{}
+		if ts.StepTemplate != nil {
+			for name, quantity := range ts.StepTemplate.Resources.Requests {
+				stepRequests[name] = quantity
+			}
+		}
+		for name, quantity := range s.Resources.Requests {
+			stepRequests[name] = quantity
+		}
+		for name, quantity := range stepRequests {
+			if current, ok := requests[name]; !ok || quantity.Cmp(current) > 0 {
+				requests[name] = quantity.DeepCopy()
+			}
+		}
+	}
+	for _, s := range ts.Sidecars {
+		for name, quantity := range s.Resources.Requests {
+			total := requests[name].DeepCopy()
+			total.Add(quantity)
+			requests[name] = total
+		}
+	}
+	return requests
+}
+
+// WorkspaceReplacements returns the replacements for the variables of the declared workspaces, given their
+// bindings and the volumes, keyed by workspace name, that the bindings are realized with:
+// $(workspaces.<name>.bound) with whether the workspace is bound, $(workspaces.<name>.path) with its mount
+// path, which is empty for an optional workspace that isn't bound, $(workspaces.<name>.claim) with the
+// name of the PersistentVolumeClaim bound to it, if any, and $(workspaces.<name>.volume) with the name of
+// its volume.
+func WorkspaceReplacements(declarations []WorkspaceDeclaration, bindings []WorkspaceBinding, volumes map[string]corev1.Volume) map[string]string {
+	bound := sets.NewString()
+	for _, b := range bindings {
+		bound.Insert(b.Name)
+	}
+
+	replacements := map[string]string{}
+	for _, d := range declarations {
+		prefix := fmt.Sprintf("workspaces.%s.", d.Name)
+		replacements[prefix+"bound"] = strconv.FormatBool(bound.Has(d.Name))
+		replacements[prefix+"path"] = d.GetMountPath()
+		if d.Optional && !bound.Has(d.Name) {
+			replacements[prefix+"path"] = ""
+		}
+		replacements[prefix+"claim"] = ""
+	}
+	for _, b := range bindings {
+		if b.PersistentVolumeClaim != nil {
+			replacements[fmt.Sprintf("workspaces.%s.claim", b.Name)] = b.PersistentVolumeClaim.ClaimName
+		} else {
+			replacements[fmt.Sprintf("workspaces.%s.claim", b.Name)] = ""
+		}
+	}
+	for name, vol := range volumes {
+		replacements[fmt.Sprintf("workspaces.%s.volume", name)] = vol.Name
+	}
+	return replacements
+}
+
+// ApplyWorkspaces returns a copy of the TaskSpec with the workspace variables in its Steps replaced, see
+// WorkspaceReplacements, for the given bindings and the volumes they're realized with. A Step that
+// overrides the mount path of a workspace gets that path instead, unless the workspace is optional and
+// isn't bound.
+func (ts *TaskSpec) ApplyWorkspaces(bindings []WorkspaceBinding, volumes map[string]corev1.Volume) *TaskSpec {
+	ts = ts.DeepCopy()
+	replacements := WorkspaceReplacements(ts.Workspaces, bindings, volumes)
+	bound := sets.NewString()
+	for _, b := range bindings {
+		bound.Insert(b.Name)
+	}
+	unbound := sets.NewString()
+	for _, w := range ts.Workspaces {
+		if w.Optional && !bound.Has(w.Name) {
+			unbound.Insert(w.Name)
+		}
+	}
+
+	for i := range ts.Steps {
+		stepReplacements := replacements
+		if len(ts.Steps[i].Workspaces) != 0 {
+			stepReplacements = make(map[string]string, len(replacements))
+			for k, v := range replacements {
+				stepReplacements[k] = v
+			}
+			for _, usage := range ts.Steps[i].Workspaces {
+				if usage.MountPath != "" && !unbound.Has(usage.Name) {
+					stepReplacements[fmt.Sprintf("workspaces.%s.path", usage.Name)] = usage.MountPath
+				}
+			}
+		}
+		ts.Steps[i].applyReplacements(stepReplacements)
+	}
+	return ts
+}
+
+// Hash returns the hex encoded sha256 of the TaskSpec, with defaults applied, in its JSON form.
+// The form is canonical since struct fields are encoded in declaration order and map keys are
+// sorted, so TaskSpecs that only differ in how they were written out or in defaulted fields have
+// the same hash. The TaskSpec itself isn't modified.
+func (ts *TaskSpec) Hash() (string, error) {
+	canonical := ts.DeepCopy()
+	canonical.SetDefaults(context.Background())
+	b, err := json.Marshal(canonical)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal TaskSpec: %w", err)
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(b)), nil
+}
+
+// Normalize returns a copy of the TaskSpec with defaults applied, the env vars and volume mounts of its Steps,
+// StepTemplate and Sidecars sorted by name, and empty lists set to nil, so that TaskSpecs that are equivalent
+// once defaulted compare equal, e.g. when diffing a desired TaskSpec against an actual one. The TaskSpec itself
+// isn't modified.
+func (ts *TaskSpec) Normalize(ctx context.Context) *TaskSpec {
+	normalized := ts.DeepCopy()
+	normalized.SetDefaults(ctx)
+	if len(normalized.Params) == 0 {
+		normalized.Params = nil
+	}
+	if len(normalized.Steps) == 0 {
+		normalized.Steps = nil
+	}
+	if len(normalized.Volumes) == 0 {
+		normalized.Volumes = nil
+	}
+	if len(normalized.Sidecars) == 0 {
+		normalized.Sidecars = nil
+	}
+	if len(normalized.Workspaces) == 0 {
+		normalized.Workspaces = nil
+	}
+	if len(normalized.Results) == 0 {
+		normalized.Results = nil
+	}
+	for i := range normalized.Params {
+		if len(normalized.Params[i].Enum) == 0 {
+			normalized.Params[i].Enum = nil
+		}
+	}
+	for i := range normalized.Steps {
+		s := &normalized.Steps[i]
+		s.Command, s.Args = normalizeStrings(s.Command), normalizeStrings(s.Args)
+		s.Env, s.VolumeMounts = normalizeEnv(s.Env), normalizeVolumeMounts(s.VolumeMounts)
+		if len(s.EnvFrom) == 0 {
+			s.EnvFrom = nil
+		}
+		if len(s.VolumeDevices) == 0 {
+			s.VolumeDevices = nil
+		}
+		if len(s.Workspaces) == 0 {
+			s.Workspaces = nil
+		}
+	}
+	if st := normalized.StepTemplate; st != nil {
+		st.Command, st.Args = normalizeStrings(st.Command), normalizeStrings(st.Args)
+		st.Env, st.VolumeMounts = normalizeEnv(st.Env), normalizeVolumeMounts(st.VolumeMounts)
+		if len(st.EnvFrom) == 0 {
+			st.EnvFrom = nil
+		}
+		if len(st.VolumeDevices) == 0 {
+			st.VolumeDevices = nil
+		}
+	}
+	for i := range normalized.Sidecars {
+		s := &normalized.Sidecars[i]
+		s.Command, s.Args = normalizeStrings(s.Command), normalizeStrings(s.Args)
+		s.Env, s.VolumeMounts = normalizeEnv(s.Env), normalizeVolumeMounts(s.VolumeMounts)
+		if len(s.Ports) == 0 {
+			s.Ports = nil
+		}
+		if len(s.EnvFrom) == 0 {
+			s.EnvFrom = nil
+		}
+		if len(s.VolumeDevices) == 0 {
+			s.VolumeDevices = nil
+		}
+		if len(s.Workspaces) == 0 {
+			s.Workspaces = nil
+		}
+	}
+	return normalized
+}
+
+func normalizeStrings(values []string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	return values
+}
+
+func normalizeEnv(env []corev1.EnvVar) []corev1.EnvVar {
+	if len(env) == 0 {
+		return nil
+	}
+	sort.SliceStable(env, func(i, j int) bool { return env[i].Name < env[j].Name })
+	return env
+}
+
+func normalizeVolumeMounts(mounts []corev1.VolumeMount) []corev1.VolumeMount {
+	if len(mounts) == 0 {
+		return nil
+	}
+	sort.SliceStable(mounts, func(i, j int) bool { return mounts[i].Name < mounts[j].Name })
+	return mounts
+}
+
 // TaskList contains a list of Task
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 type TaskList struct {
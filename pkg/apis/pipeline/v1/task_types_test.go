@@ -0,0 +1,689 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func TestTaskSpec_ResultNames(t *testing.T) {
+	for _, tc := range []struct {
+		name              string
+		results           []v1.TaskResult
+		wantNames         sets.String
+		wantResultsByName map[string]v1.TaskResult
+	}{{
+		name:              "no results",
+		wantNames:         sets.NewString(),
+		wantResultsByName: map[string]v1.TaskResult{},
+	}, {
+		name: "string and object results",
+		results: []v1.TaskResult{{
+			Name: "digest",
+			Type: v1.ResultsTypeString,
+		}, {
+			Name: "image",
+			Type: v1.ResultsTypeObject,
+			Properties: map[string]v1.PropertySpec{
+				"url":    {Type: v1.ParamTypeString},
+				"digest": {Type: v1.ParamTypeString},
+			},
+		}},
+		wantNames: sets.NewString("digest", "image"),
+		wantResultsByName: map[string]v1.TaskResult{
+			"digest": {
+				Name: "digest",
+				Type: v1.ResultsTypeString,
+			},
+			"image": {
+				Name: "image",
+				Type: v1.ResultsTypeObject,
+				Properties: map[string]v1.PropertySpec{
+					"url":    {Type: v1.ParamTypeString},
+					"digest": {Type: v1.ParamTypeString},
+				},
+			},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ts := v1.TaskSpec{Results: tc.results}
+			if d := cmp.Diff(tc.wantNames, ts.ResultNames()); d != "" {
+				t.Errorf("TaskSpec.ResultNames() %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(tc.wantResultsByName, ts.ResultsByName()); d != "" {
+				t.Errorf("TaskSpec.ResultsByName() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskSpec_StepByName(t *testing.T) {
+	ts := &v1.TaskSpec{
+		Steps: []v1.Step{{
+			Image: "unnamed",
+		}, {
+			Name:  "build",
+			Image: "golang",
+		}, {
+			Name:  "push",
+			Image: "ko",
+		}},
+	}
+	for _, tc := range []struct {
+		name      string
+		stepName  string
+		wantImage string
+		wantIndex int
+	}{{
+		name:      "found",
+		stepName:  "push",
+		wantImage: "ko",
+		wantIndex: 2,
+	}, {
+		name:      "not found",
+		stepName:  "test",
+		wantIndex: -1,
+	}, {
+		name:      "empty name",
+		stepName:  "",
+		wantIndex: -1,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			step, i := ts.StepByName(tc.stepName)
+			if i != tc.wantIndex {
+				t.Errorf("TaskSpec.StepByName() index = %d, want %d", i, tc.wantIndex)
+			}
+			if tc.wantIndex == -1 {
+				if step != nil {
+					t.Errorf("TaskSpec.StepByName() = %v, want nil", step)
+				}
+				return
+			}
+			if step != &ts.Steps[i] {
+				t.Errorf("TaskSpec.StepByName() doesn't point to the Step in the TaskSpec")
+			}
+			if step.Image != tc.wantImage {
+				t.Errorf("TaskSpec.StepByName() image = %s, want %s", step.Image, tc.wantImage)
+			}
+		})
+	}
+}
+
+func TestTaskSpec_NativeSidecarSteps(t *testing.T) {
+	always, onFailure := corev1.RestartPolicyAlways, corev1.RestartPolicyOnFailure
+	ts := &v1.TaskSpec{
+		Steps: []v1.Step{{
+			Name:          "proxy",
+			Image:         "envoy",
+			RestartPolicy: &always,
+		}, {
+			Name:          "flaky",
+			Image:         "my-image",
+			RestartPolicy: &onFailure,
+		}, {
+			Name:  "build",
+			Image: "my-image",
+		}},
+	}
+	want := []v1.Step{ts.Steps[0]}
+	if d := cmp.Diff(want, ts.NativeSidecarSteps()); d != "" {
+		t.Errorf("TaskSpec.NativeSidecarSteps() %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestTaskSpec_Images(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		ts   v1.TaskSpec
+		want []string
+	}{{
+		name: "no steps",
+		want: []string{},
+	}, {
+		name: "steps sharing an image and a sidecar",
+		ts: v1.TaskSpec{
+			Steps: []v1.Step{{
+				Name:  "build",
+				Image: "golang",
+			}, {
+				Name:  "test",
+				Image: "golang",
+			}},
+			Sidecars: []v1.Sidecar{{
+				Name:  "registry",
+				Image: "registry:2",
+			}},
+		},
+		want: []string{"golang", "registry:2"},
+	}, {
+		name: "image from step template",
+		ts: v1.TaskSpec{
+			StepTemplate: &v1.StepTemplate{
+				Image: "ubuntu",
+			},
+			Steps: []v1.Step{{
+				Name: "inherit",
+			}, {
+				Name:  "override",
+				Image: "alpine",
+			}},
+		},
+		want: []string{"alpine", "ubuntu"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if d := cmp.Diff(tc.want, tc.ts.Images()); d != "" {
+				t.Errorf("TaskSpec.Images() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskSpec_PrivilegedSteps(t *testing.T) {
+	privileged, unprivileged := true, false
+	for _, tc := range []struct {
+		name string
+		ts   v1.TaskSpec
+		want []string
+	}{{
+		name: "privileged, capabilities and benign steps",
+		ts: v1.TaskSpec{
+			Steps: []v1.Step{{
+				Name:            "privileged",
+				Image:           "docker:dind",
+				SecurityContext: &corev1.SecurityContext{Privileged: &privileged},
+			}, {
+				Name:  "capabilities",
+				Image: "busybox",
+				SecurityContext: &corev1.SecurityContext{
+					Capabilities: &corev1.Capabilities{Add: []corev1.Capability{"NET_ADMIN"}},
+				},
+			}, {
+				Name:  "benign",
+				Image: "busybox",
+			}},
+		},
+		want: []string{"step-privileged"},
+	}, {
+		name: "unnamed privileged step",
+		ts: v1.TaskSpec{
+			Steps: []v1.Step{{
+				Name:  "benign",
+				Image: "busybox",
+			}, {
+				Image:           "docker:dind",
+				SecurityContext: &corev1.SecurityContext{Privileged: &privileged},
+			}},
+		},
+		want: []string{"step-unnamed-1"},
+	}, {
+		name: "privileged step template",
+		ts: v1.TaskSpec{
+			StepTemplate: &v1.StepTemplate{
+				SecurityContext: &corev1.SecurityContext{Privileged: &privileged},
+			},
+			Steps: []v1.Step{{
+				Name:  "inherits",
+				Image: "busybox",
+			}, {
+				Name:            "overrides",
+				Image:           "busybox",
+				SecurityContext: &corev1.SecurityContext{Privileged: &unprivileged},
+			}},
+		},
+		want: []string{"step-inherits"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if d := cmp.Diff(tc.want, tc.ts.PrivilegedSteps()); d != "" {
+				t.Errorf("TaskSpec.PrivilegedSteps() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskSpec_EnvNames(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		ts   v1.TaskSpec
+		want sets.String
+	}{{
+		name: "template env overridden in a step",
+		ts: v1.TaskSpec{
+			StepTemplate: &v1.StepTemplate{
+				Env: []corev1.EnvVar{{Name: "HOME", Value: "/tekton/home"}},
+			},
+			Steps: []v1.Step{{
+				Name:  "build",
+				Image: "ubuntu",
+				Env:   []corev1.EnvVar{{Name: "HOME", Value: "/root"}},
+			}},
+		},
+		want: sets.NewString("HOME"),
+	}, {
+		name: "distinct names across steps and sidecars",
+		ts: v1.TaskSpec{
+			Steps: []v1.Step{{
+				Name:  "build",
+				Image: "ubuntu",
+				Env:   []corev1.EnvVar{{Name: "GOFLAGS", Value: "-mod=vendor"}},
+			}, {
+				Name:    "push",
+				Image:   "ubuntu",
+				Env:     []corev1.EnvVar{{Name: "REGISTRY", Value: "gcr.io"}},
+				EnvFrom: []corev1.EnvFromSource{{ConfigMapRef: &corev1.ConfigMapEnvSource{}}},
+			}},
+			Sidecars: []v1.Sidecar{{
+				Name:  "registry",
+				Image: "registry",
+				Env:   []corev1.EnvVar{{Name: "REGISTRY_HTTP_ADDR", Value: ":5000"}},
+			}},
+		},
+		want: sets.NewString("GOFLAGS", "REGISTRY", "REGISTRY_HTTP_ADDR"),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if d := cmp.Diff(tc.want, tc.ts.EnvNames()); d != "" {
+				t.Errorf("TaskSpec.EnvNames() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskSpec_ReferencedParams(t *testing.T) {
+	ts := v1.TaskSpec{
+		Steps: []v1.Step{{
+			Name:   "script",
+			Image:  "ubuntu",
+			Script: "echo $(params.message) $(params.image.tag)",
+		}, {
+			Name:  "args",
+			Image: "ubuntu",
+			Args:  []string{"$(params.flags[*])", "$(params.files[0])"},
+		}, {
+			Name:  "env",
+			Image: "ubuntu",
+			Env: []corev1.EnvVar{{
+				Name:  "GREETING",
+				Value: `$(params["greeting"])`,
+			}},
+		}},
+		Sidecars: []v1.Sidecar{{
+			Name:  "sidecar",
+			Image: "$(params.sidecar-image)",
+		}},
+		Workspaces: []v1.WorkspaceDeclaration{{
+			Name:      "source",
+			MountPath: "/workspace/$(params.dir)",
+		}},
+	}
+	want := sets.NewString("message", "image", "flags", "files", "greeting", "sidecar-image", "dir")
+	got, err := ts.ReferencedParams()
+	if err != nil {
+		t.Fatalf("TaskSpec.ReferencedParams() = %v", err)
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("TaskSpec.ReferencedParams() %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestTaskSpec_ConditionalWorkspaces(t *testing.T) {
+	pushEnabled := v1.WhenExpressions{{
+		Input:    "$(params.push)",
+		Operator: selection.In,
+		Values:   []string{"true"},
+	}}
+	ts := v1.TaskSpec{
+		Params: []v1.ParamSpec{{Name: "push", Type: v1.ParamTypeString}},
+		Steps: []v1.Step{{
+			Name:   "build",
+			Image:  "ubuntu",
+			Script: "make -C $(workspaces.source.path)",
+		}, {
+			Name:   "push",
+			Image:  "ubuntu",
+			When:   pushEnabled,
+			Script: "cp $(workspaces.source.path)/out $(workspaces.registry-credentials.path)",
+		}, {
+			Name:       "cache",
+			Image:      "ubuntu",
+			When:       pushEnabled,
+			Workspaces: []v1.WorkspaceUsage{{Name: "cache", MountPath: "/cache"}},
+		}, {
+			Name:   "report",
+			Image:  "ubuntu",
+			When:   pushEnabled,
+			Script: "ls $(workspaces.reports.path)",
+		}},
+		Sidecars: []v1.Sidecar{{
+			Name:       "uploader",
+			Image:      "ubuntu",
+			Workspaces: []v1.WorkspaceUsage{{Name: "reports"}},
+		}},
+		Workspaces: []v1.WorkspaceDeclaration{{
+			Name: "source",
+		}, {
+			Name:     "registry-credentials",
+			Optional: true,
+		}, {
+			Name:     "cache",
+			Optional: true,
+		}, {
+			// also used by a sidecar, which always runs
+			Name:     "reports",
+			Optional: true,
+		}, {
+			Name:     "unused",
+			Optional: true,
+		}},
+	}
+	want := sets.NewString("registry-credentials", "cache")
+	got, err := ts.ConditionalWorkspaces()
+	if err != nil {
+		t.Fatalf("TaskSpec.ConditionalWorkspaces() = %v", err)
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("TaskSpec.ConditionalWorkspaces() %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestTaskSpec_EffectiveResourceRequests(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		ts   v1.TaskSpec
+		want corev1.ResourceList
+	}{{
+		name: "no requests",
+		ts: v1.TaskSpec{
+			Steps: []v1.Step{{Name: "step", Image: "busybox"}},
+		},
+		want: corev1.ResourceList{},
+	}, {
+		name: "steps with differing requests and a sidecar",
+		ts: v1.TaskSpec{
+			Steps: []v1.Step{{
+				Name:  "build",
+				Image: "golang",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("2"),
+						corev1.ResourceMemory: resource.MustParse("512Mi"),
+					},
+				},
+			}, {
+				Name:  "test",
+				Image: "golang",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("500m"),
+						corev1.ResourceMemory: resource.MustParse("1Gi"),
+					},
+				},
+			}},
+			Sidecars: []v1.Sidecar{{
+				Name:  "registry",
+				Image: "registry:2",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("250m"),
+						corev1.ResourceMemory: resource.MustParse("128Mi"),
+					},
+				},
+			}},
+		},
+		want: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("2250m"),
+			corev1.ResourceMemory: resource.MustParse("1152Mi"),
+		},
+	}, {
+		name: "requests from step template",
+		ts: v1.TaskSpec{
+			StepTemplate: &v1.StepTemplate{
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("1"),
+						corev1.ResourceMemory: resource.MustParse("256Mi"),
+					},
+				},
+			},
+			Steps: []v1.Step{{
+				Name:  "inherits",
+				Image: "busybox",
+			}, {
+				Name:  "overrides",
+				Image: "busybox",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceMemory: resource.MustParse("2Gi"),
+					},
+				},
+			}},
+		},
+		want: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("1"),
+			corev1.ResourceMemory: resource.MustParse("2Gi"),
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.ts.EffectiveResourceRequests()
+			if d := cmp.Diff(tc.want, got, cmp.Comparer(func(x, y resource.Quantity) bool {
+				return x.Cmp(y) == 0
+			})); d != "" {
+				t.Errorf("TaskSpec.EffectiveResourceRequests() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskSpec_ApplyWorkspaces(t *testing.T) {
+	ts := &v1.TaskSpec{
+		Workspaces: []v1.WorkspaceDeclaration{{
+			Name: "source",
+		}, {
+			Name:      "cache",
+			MountPath: "/cache",
+		}, {
+			Name:     "credentials",
+			Optional: true,
+		}},
+		Steps: []v1.Step{{
+			Name:       "build",
+			Image:      "golang",
+			WorkingDir: "$(workspaces.source.path)",
+			Args:       []string{"-cache=$(workspaces.cache.path)", "-claim=$(workspaces.source.claim)", "-volume=$(workspaces.source.volume)"},
+			Script:     "if [ $(workspaces.credentials.bound) = true ]; then cp $(workspaces.credentials.path)/token .; fi",
+		}, {
+			Name:  "isolated",
+			Image: "busybox",
+			Args:  []string{"$(workspaces.source.path)"},
+			Workspaces: []v1.WorkspaceUsage{{
+				Name:      "source",
+				MountPath: "/src",
+			}},
+		}},
+	}
+	bindings := []v1.WorkspaceBinding{{
+		Name: "source",
+		PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+			ClaimName: "my-pvc",
+		},
+	}, {
+		Name:     "cache",
+		EmptyDir: &corev1.EmptyDirVolumeSource{},
+	}}
+	want := []v1.Step{{
+		Name:       "build",
+		Image:      "golang",
+		WorkingDir: "/workspace/source",
+		Args:       []string{"-cache=/cache", "-claim=my-pvc", "-volume=ws-source"},
+		Script:     "if [ false = true ]; then cp /token .; fi",
+	}, {
+		Name:  "isolated",
+		Image: "busybox",
+		Args:  []string{"/src"},
+		Workspaces: []v1.WorkspaceUsage{{
+			Name:      "source",
+			MountPath: "/src",
+		}},
+	}}
+	volumes := map[string]corev1.Volume{
+		"source": {Name: "ws-source"},
+		"cache":  {Name: "ws-cache"},
+	}
+	original := ts.DeepCopy()
+	got := ts.ApplyWorkspaces(bindings, volumes)
+	if d := cmp.Diff(want, got.Steps); d != "" {
+		t.Errorf("TaskSpec.ApplyWorkspaces() steps %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff(original, ts); d != "" {
+		t.Errorf("TaskSpec.ApplyWorkspaces() modified the TaskSpec %s", diff.PrintWantGot(d))
+	}
+
+	bound := ts.ApplyWorkspaces(append(bindings, v1.WorkspaceBinding{
+		Name:     "credentials",
+		EmptyDir: &corev1.EmptyDirVolumeSource{},
+	}), volumes)
+	wantScript := "if [ true = true ]; then cp /workspace/credentials/token .; fi"
+	if d := cmp.Diff(wantScript, bound.Steps[0].Script); d != "" {
+		t.Errorf("TaskSpec.ApplyWorkspaces() script with the optional workspace bound %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestWorkspaceReplacements_RequiredWorkspaceNotBound(t *testing.T) {
+	declarations := []v1.WorkspaceDeclaration{{
+		Name: "source",
+	}}
+	want := map[string]string{
+		"workspaces.source.bound": "false",
+		"workspaces.source.path":  "/workspace/source",
+		"workspaces.source.claim": "",
+	}
+	got := v1.WorkspaceReplacements(declarations, nil, nil)
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("WorkspaceReplacements() %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestTaskSpec_Hash(t *testing.T) {
+	var ts1, ts2 v1.TaskSpec
+	if err := json.Unmarshal([]byte(`{
+		"params": [{"name": "revision", "type": "string", "default": "main"}],
+		"steps": [{"name": "build", "image": "golang", "env": [{"name": "GOOS", "value": "linux"}]}],
+		"results": [{"name": "digest", "type": "string"}]
+	}`), &ts1); err != nil {
+		t.Fatalf("failed to unmarshal TaskSpec: %v", err)
+	}
+	// The same spec with its keys in another order and the param and result types left to be defaulted.
+	if err := json.Unmarshal([]byte(`{
+		"results": [{"name": "digest"}],
+		"steps": [{"env": [{"value": "linux", "name": "GOOS"}], "image": "golang", "name": "build"}],
+		"params": [{"default": "main", "name": "revision"}]
+	}`), &ts2); err != nil {
+		t.Fatalf("failed to unmarshal TaskSpec: %v", err)
+	}
+	original := ts2.DeepCopy()
+
+	hash1, err := ts1.Hash()
+	if err != nil {
+		t.Fatalf("Hash() returned unexpected error: %v", err)
+	}
+	hash2, err := ts2.Hash()
+	if err != nil {
+		t.Fatalf("Hash() returned unexpected error: %v", err)
+	}
+	if hash1 != hash2 {
+		t.Errorf("Hash() of equivalent TaskSpecs differ: %s != %s", hash1, hash2)
+	}
+	if d := cmp.Diff(original, &ts2); d != "" {
+		t.Errorf("Hash() modified the TaskSpec %s", diff.PrintWantGot(d))
+	}
+
+	ts2.Steps[0].Image = "golang:1.18"
+	hash3, err := ts2.Hash()
+	if err != nil {
+		t.Fatalf("Hash() returned unexpected error: %v", err)
+	}
+	if hash3 == hash1 {
+		t.Errorf("Hash() didn't change when the step image changed: %s", hash3)
+	}
+}
+
+func TestTaskSpec_Normalize(t *testing.T) {
+	ts1 := &v1.TaskSpec{
+		Params: []v1.ParamSpec{{Name: "revision", Type: v1.ParamTypeString}},
+		Steps: []v1.Step{{
+			Name:  "build",
+			Image: "golang",
+			Args:  []string{},
+			Env: []corev1.EnvVar{
+				{Name: "GOOS", Value: "linux"},
+				{Name: "CGO_ENABLED", Value: "0"},
+			},
+			VolumeMounts: []corev1.VolumeMount{
+				{Name: "source", MountPath: "/src"},
+				{Name: "cache", MountPath: "/cache"},
+			},
+		}},
+		Sidecars: []v1.Sidecar{{Image: "docker:dind", Env: []corev1.EnvVar{}}},
+		Results:  []v1.TaskResult{{Name: "digest", Type: v1.ResultsTypeString}},
+	}
+	// The same spec with the env vars and volume mounts in another order, nil instead of empty lists
+	// and the types and sidecar name left to be defaulted.
+	ts2 := &v1.TaskSpec{
+		Params: []v1.ParamSpec{{Name: "revision"}},
+		Steps: []v1.Step{{
+			Name:  "build",
+			Image: "golang",
+			Env: []corev1.EnvVar{
+				{Name: "CGO_ENABLED", Value: "0"},
+				{Name: "GOOS", Value: "linux"},
+			},
+			VolumeMounts: []corev1.VolumeMount{
+				{Name: "cache", MountPath: "/cache"},
+				{Name: "source", MountPath: "/src"},
+			},
+		}},
+		Sidecars:   []v1.Sidecar{{Image: "docker:dind"}},
+		Volumes:    []corev1.Volume{},
+		Workspaces: []v1.WorkspaceDeclaration{},
+		Results:    []v1.TaskResult{{Name: "digest"}},
+	}
+	original := ts2.DeepCopy()
+
+	got1, got2 := ts1.Normalize(context.Background()), ts2.Normalize(context.Background())
+	if d := cmp.Diff(got1, got2); d != "" {
+		t.Errorf("Normalize() of equivalent TaskSpecs differ %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff(original, ts2); d != "" {
+		t.Errorf("Normalize() modified the TaskSpec %s", diff.PrintWantGot(d))
+	}
+
+	want := []corev1.EnvVar{{Name: "CGO_ENABLED", Value: "0"}, {Name: "GOOS", Value: "linux"}}
+	if d := cmp.Diff(want, got1.Steps[0].Env); d != "" {
+		t.Errorf("Normalize() didn't sort the env vars %s", diff.PrintWantGot(d))
+	}
+	if got1.Sidecars[0].Name != "sidecar-0" {
+		t.Errorf("Normalize() didn't apply defaults, got sidecar name %q", got1.Sidecars[0].Name)
+	}
+}
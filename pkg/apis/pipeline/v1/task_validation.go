@@ -30,6 +30,8 @@ import (
 	"github.com/tektoncd/pipeline/pkg/list"
 	"github.com/tektoncd/pipeline/pkg/substitution"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"knative.dev/pkg/apis"
@@ -50,6 +52,21 @@ var _ apis.Validatable = (*Task)(nil)
 var stringAndArrayVariableNameFormatRegex = regexp.MustCompile(stringAndArrayVariableNameFormat)
 var objectVariableNameFormatRegex = regexp.MustCompile(objectVariableNameFormat)
 
+// envFieldPathRegex matches the syntax of a downward API field path, e.g. status.podIP or
+// metadata.labels['<key>']. Which paths are supported is left to the API server, since it changes
+// between Kubernetes versions.
+var envFieldPathRegex = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9]*(\.[a-zA-Z][a-zA-Z0-9]*)*(\['[^']+'\])?$`)
+
+// envResources are the container resources a container's env vars can refer to, besides huge pages.
+var envResources = sets.NewString(
+	"limits.cpu",
+	"limits.memory",
+	"limits.ephemeral-storage",
+	"requests.cpu",
+	"requests.memory",
+	"requests.ephemeral-storage",
+)
+
 // Validate implements apis.Validatable
 func (t *Task) Validate(ctx context.Context) *apis.FieldError {
 	errs := validate.ObjectMetadata(t.GetObjectMeta()).ViaField("metadata")
@@ -86,10 +103,57 @@ func (ts *TaskSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 	}
 
 	errs = errs.Also(validateSteps(ctx, mergedSteps).ViaField("steps"))
+	errs = errs.Also(validateSidecars(ts.Sidecars, ts.Steps).ViaField("sidecars"))
 	errs = errs.Also(ValidateParameterTypes(ctx, ts.Params).ViaField("params"))
+	errs = errs.Also(validateParamDefaultCycles(ts.Params))
 	errs = errs.Also(ValidateParameterVariables(ctx, ts.Steps, ts.Params))
 	errs = errs.Also(validateTaskContextVariables(ctx, ts.Steps))
 	errs = errs.Also(validateResults(ctx, ts.Results).ViaField("results"))
+	errs = errs.Also(validateStdoutResults(ts.Steps, ts.Results).ViaField("steps"))
+	return errs
+}
+
+// ValidateStepTimeouts returns an error naming each of the TaskSpec's Steps whose timeout exceeds the
+// given timeout of the Task. A nil or zero Task timeout means no timeout, so nothing is checked.
+func (ts *TaskSpec) ValidateStepTimeouts(taskTimeout *metav1.Duration) error {
+	if taskTimeout == nil || taskTimeout.Duration == 0 {
+		return nil
+	}
+	var errs *apis.FieldError
+	for i, s := range ts.Steps {
+		if s.Timeout != nil && s.Timeout.Duration > taskTimeout.Duration {
+			errs = errs.Also(apis.ErrInvalidValue(s.Timeout.Duration, "timeout",
+				fmt.Sprintf("step timeout exceeds the task timeout of %s", taskTimeout.Duration)).ViaFieldIndex("steps", i))
+		}
+	}
+	if errs == nil {
+		return nil
+	}
+	return errs
+}
+
+// ValidateParamUsage returns an error naming each param referenced by the TaskSpec that it neither
+// declares nor is given in propagated. Params are only propagated to embedded TaskSpecs when the
+// "enable-api-fields" feature gate is "alpha", so propagated is ignored otherwise.
+func (ts *TaskSpec) ValidateParamUsage(ctx context.Context, propagated []string) error {
+	declared := sets.NewString()
+	for _, p := range ts.Params {
+		declared.Insert(p.Name)
+	}
+	if config.FromContextOrDefaults(ctx).FeatureFlags.EnableAPIFields == config.AlphaAPIFields {
+		declared.Insert(propagated...)
+	}
+	referenced, err := ts.ReferencedParams()
+	if err != nil {
+		return err
+	}
+	var errs *apis.FieldError
+	for _, name := range referenced.Difference(declared).List() {
+		errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("param %q is referenced but not declared", name), "params"))
+	}
+	if errs == nil {
+		return nil
+	}
 	return errs
 }
 
@@ -100,6 +164,26 @@ func validateResults(ctx context.Context, results []TaskResult) (errs *apis.Fiel
 	return errs
 }
 
+// validateStdoutResults checks that the results Steps capture their stdout into are string results,
+// since the whole stream is written to them as is.
+func validateStdoutResults(steps []Step, results []TaskResult) (errs *apis.FieldError) {
+	types := map[string]ResultsType{}
+	for _, r := range results {
+		types[r.Name] = r.Type
+	}
+	for i := range steps {
+		name, ok := steps[i].StdoutResult()
+		if !ok {
+			continue
+		}
+		if t, ok := types[name]; ok && t != "" && t != ResultsTypeString {
+			errs = errs.Also(apis.ErrInvalidValue(steps[i].StdoutConfig.Path, "stdoutConfig.path",
+				fmt.Sprintf("stdout can only be captured into a string result, but result %q is of type %s", name, t)).ViaIndex(i))
+		}
+	}
+	return errs
+}
+
 // a mount path which conflicts with any other declared workspaces, with the explicitly
 // declared volume mounts, or with the stepTemplate. The names must also be unique.
 func validateDeclaredWorkspaces(workspaces []WorkspaceDeclaration, steps []Step, stepTemplate *StepTemplate) (errs *apis.FieldError) {
@@ -190,12 +274,76 @@ func ValidateVolumes(volumes []corev1.Volume) (errs *apis.FieldError) {
 func validateSteps(ctx context.Context, steps []Step) (errs *apis.FieldError) {
 	// Task must not have duplicate step names.
 	names := sets.NewString()
+	regularStep := false
 	for idx, s := range steps {
 		errs = errs.Also(validateStep(ctx, s, names).ViaIndex(idx))
+		// Native sidecars are started as init containers, before any of the other steps.
+		if !s.IsNativeSidecar() {
+			regularStep = true
+		} else if regularStep {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("native sidecar step %q must come before the other steps", s.Name), "restartPolicy").ViaIndex(idx))
+		}
 	}
 	return errs
 }
 
+func validateSidecars(sidecars []Sidecar, steps []Step) (errs *apis.FieldError) {
+	stepNames := sets.NewString()
+	for _, s := range steps {
+		stepNames.Insert(s.Name)
+	}
+	// Unnamed sidecars are given unique names when defaulted, so only named sidecars can collide.
+	names := sets.NewString()
+	for idx, s := range sidecars {
+		if s.Name != "" {
+			if names.Has(s.Name) {
+				errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("multiple sidecars with same name %q", s.Name), "name").ViaIndex(idx))
+			}
+			if stepNames.Has(s.Name) {
+				errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("sidecar name %q is also the name of a step", s.Name), "name").ViaIndex(idx))
+			}
+			names.Insert(s.Name)
+		}
+		errs = errs.Also(validateProbe(s.LivenessProbe, s.Ports).ViaField("livenessProbe").ViaIndex(idx))
+		errs = errs.Also(validateProbe(s.ReadinessProbe, s.Ports).ViaField("readinessProbe").ViaIndex(idx))
+		errs = errs.Also(validateProbe(s.StartupProbe, s.Ports).ViaField("startupProbe").ViaIndex(idx))
+	}
+	return errs
+}
+
+// validateProbe checks that the ports used by the probe are either valid port numbers
+// or the names of ports declared by the container.
+func validateProbe(probe *corev1.Probe, ports []corev1.ContainerPort) (errs *apis.FieldError) {
+	if probe == nil {
+		return nil
+	}
+	if probe.HTTPGet != nil {
+		errs = errs.Also(validateProbePort(probe.HTTPGet.Port, ports).ViaField("httpGet"))
+	}
+	if probe.TCPSocket != nil {
+		errs = errs.Also(validateProbePort(probe.TCPSocket.Port, ports).ViaField("tcpSocket"))
+	}
+	if probe.GRPC != nil {
+		errs = errs.Also(validateProbePort(intstr.FromInt(int(probe.GRPC.Port)), ports).ViaField("grpc"))
+	}
+	return errs
+}
+
+func validateProbePort(port intstr.IntOrString, ports []corev1.ContainerPort) *apis.FieldError {
+	if port.Type == intstr.String {
+		for _, p := range ports {
+			if p.Name == port.StrVal {
+				return nil
+			}
+		}
+		return apis.ErrInvalidValue(port.StrVal, "port", "port name must match a port declared by the container")
+	}
+	if e := validation.IsValidPortNum(port.IntValue()); len(e) > 0 {
+		return apis.ErrInvalidValue(port.IntValue(), "port", strings.Join(e, ", "))
+	}
+	return nil
+}
+
 func validateStep(ctx context.Context, s Step, names sets.String) (errs *apis.FieldError) {
 	if s.Image == "" {
 		errs = errs.Also(apis.ErrMissingField("Image"))
@@ -210,6 +358,7 @@ func validateStep(ctx context.Context, s Step, names sets.String) (errs *apis.Fi
 		}
 	}
 
+	// Unnamed steps get unique container names from their index, so only named steps can collide.
 	if s.Name != "" {
 		if names.Has(s.Name) {
 			errs = errs.Also(apis.ErrInvalidValue(s.Name, "name"))
@@ -230,6 +379,41 @@ func validateStep(ctx context.Context, s Step, names sets.String) (errs *apis.Fi
 		}
 	}
 
+	// Retries and RetryBackoff are alpha features and will fail validation if they're used in a task spec
+	// when the enable-api-fields feature gate is not "alpha".
+	if s.Retries != 0 {
+		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "step retries", config.AlphaAPIFields).ViaField("retries"))
+		if s.Retries < 0 {
+			errs = errs.Also(apis.ErrInvalidValue(s.Retries, "retries", "retries must not be negative"))
+		}
+	}
+	if s.RetryBackoff != nil {
+		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "step retry backoff", config.AlphaAPIFields).ViaField("retryBackoff"))
+		if s.RetryBackoff.Duration <= 0 {
+			errs = errs.Also(apis.ErrInvalidValue(s.RetryBackoff.Duration, "retryBackoff", "retry backoff must be positive"))
+		}
+	}
+
+	// RestartPolicy is an alpha feature and will fail validation if it's used in a task spec
+	// when the enable-api-fields feature gate is not "alpha".
+	if s.RestartPolicy != nil {
+		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "native sidecar steps", config.AlphaAPIFields).ViaField("restartPolicy"))
+		if *s.RestartPolicy != corev1.RestartPolicyAlways {
+			errs = errs.Also(apis.ErrInvalidValue(*s.RestartPolicy, "restartPolicy", fmt.Sprintf("restartPolicy can only be %q", corev1.RestartPolicyAlways)))
+		}
+	}
+
+	// When is an alpha feature and will fail validation if it's used in a task spec
+	// when the enable-api-fields feature gate is not "alpha".
+	if len(s.When) > 0 {
+		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "step when expressions", config.AlphaAPIFields).ViaField("when"))
+		errs = errs.Also(s.When.validate())
+	}
+
+	for j, e := range s.Env {
+		errs = errs.Also(validateEnvValueFrom(e.ValueFrom).ViaFieldIndex("env", j))
+	}
+
 	for j, vm := range s.VolumeMounts {
 		if strings.HasPrefix(vm.MountPath, "/tekton/") &&
 			!strings.HasPrefix(vm.MountPath, "/tekton/home") {
@@ -241,7 +425,7 @@ func validateStep(ctx context.Context, s Step, names sets.String) (errs *apis.Fi
 	}
 
 	if s.OnError != "" {
-		if s.OnError != "continue" && s.OnError != "stopAndFail" {
+		if s.OnError != StepOnErrorContinue && s.OnError != StepOnErrorStopAndFail {
 			errs = errs.Also(&apis.FieldError{
 				Message: fmt.Sprintf("invalid value: %v", s.OnError),
 				Paths:   []string{"onError"},
@@ -256,9 +440,118 @@ func validateStep(ctx context.Context, s Step, names sets.String) (errs *apis.Fi
 			errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "windows script support", config.AlphaAPIFields).ViaField("script"))
 		}
 	}
+
+	// StdoutConfig is an alpha feature and will fail validation if it's used in a task spec
+	// when the enable-api-fields feature gate is not "alpha".
+	if s.StdoutConfig != nil {
+		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "step stdout stream support", config.AlphaAPIFields).ViaField("stdoutconfig"))
+	}
+	// StderrConfig is an alpha feature and will fail validation if it's used in a task spec
+	// when the enable-api-fields feature gate is not "alpha".
+	if s.StderrConfig != nil {
+		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "step stderr stream support", config.AlphaAPIFields).ViaField("stderrconfig"))
+	}
+	return errs
+}
+
+// validateEnvValueFrom checks that an env var's downward API field path is well formed and that its resource
+// reference is supported in a container, since these are otherwise only rejected when the TaskRun's pod is
+// created.
+func validateEnvValueFrom(vf *corev1.EnvVarSource) (errs *apis.FieldError) {
+	if vf == nil {
+		return nil
+	}
+	if vf.FieldRef != nil {
+		path := vf.FieldRef.FieldPath
+		if !envFieldPathRegex.MatchString(path) {
+			errs = errs.Also(apis.ErrInvalidValue(path, "valueFrom.fieldRef.fieldPath",
+				"must be a dot-separated field path, optionally ending in a ['<key>'] subscript"))
+		}
+	}
+	if vf.ResourceFieldRef != nil {
+		resource := vf.ResourceFieldRef.Resource
+		if !envResources.Has(resource) && !strings.HasPrefix(resource, "limits."+corev1.ResourceHugePagesPrefix) && !strings.HasPrefix(resource, "requests."+corev1.ResourceHugePagesPrefix) {
+			errs = errs.Also(apis.ErrInvalidValue(resource, "valueFrom.resourceFieldRef.resource",
+				fmt.Sprintf("supported values: %s, limits.hugepages-<size>, requests.hugepages-<size>", strings.Join(envResources.List(), ", "))))
+		}
+	}
+	return errs
+}
+
+// Validate checks that the ParamSpec has a valid type that its default value matches, and that an object
+// default provides all of the declared properties.
+func (p ParamSpec) Validate(ctx context.Context) *apis.FieldError {
+	errs := ValidateParameterTypes(ctx, []ParamSpec{p})
+	if p.Type == ParamTypeObject {
+		errs = errs.Also(ValidateObjectKeys(p.Properties, p.Default).ViaField(p.Name))
+	}
 	return errs
 }
 
+// validateParamDefaultCycles checks that the params' default values don't reference each other in a
+// cycle, such as a default of $(params.b) for a and of $(params.a) for b, which could never be resolved.
+// Only the first cycle found is reported.
+func validateParamDefaultCycles(params []ParamSpec) *apis.FieldError {
+	declared := sets.NewString()
+	for _, p := range params {
+		declared.Insert(p.Name)
+	}
+	deps := map[string][]string{}
+	var errs *apis.FieldError
+	for _, p := range params {
+		if p.Default == nil {
+			continue
+		}
+		values := append([]string{p.Default.StringVal}, p.Default.ArrayVal...)
+		for _, v := range p.Default.ObjectVal {
+			values = append(values, v)
+		}
+		referenced := sets.NewString()
+		for _, v := range values {
+			names, err := substitution.ExtractVariableNames(v, ParamsPrefix)
+			if err != nil {
+				errs = errs.Also(apis.ErrGeneric(err.Error(), "default").ViaFieldKey("params", p.Name))
+				continue
+			}
+			referenced.Insert(names...)
+		}
+		deps[p.Name] = referenced.Intersection(declared).List()
+	}
+	if errs != nil {
+		return errs
+	}
+
+	// depth-first search, keeping the path of params being visited to report the cycle
+	visited := sets.NewString()
+	var path []string
+	var visit func(name string) []string
+	visit = func(name string) []string {
+		for i, n := range path {
+			if n == name {
+				return append(append([]string{}, path[i:]...), name)
+			}
+		}
+		if visited.Has(name) {
+			return nil
+		}
+		visited.Insert(name)
+		path = append(path, name)
+		for _, dep := range deps[name] {
+			if cycle := visit(dep); cycle != nil {
+				return cycle
+			}
+		}
+		path = path[:len(path)-1]
+		return nil
+	}
+	for _, p := range params {
+		if cycle := visit(p.Name); cycle != nil {
+			return apis.ErrGeneric(fmt.Sprintf("param defaults reference each other in a cycle: %s", strings.Join(cycle, " -> ")), "params")
+		}
+	}
+	return nil
+}
+
 // ValidateParameterTypes validates all the types within a slice of ParamSpecs
 func ValidateParameterTypes(ctx context.Context, params []ParamSpec) (errs *apis.FieldError) {
 	for _, p := range params {
@@ -267,11 +560,32 @@ func ValidateParameterTypes(ctx context.Context, params []ParamSpec) (errs *apis
 			// when the enable-api-fields feature gate is not "alpha".
 			errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "object type parameter", config.AlphaAPIFields))
 		}
+		if len(p.Enum) != 0 {
+			// Enum is an alpha feature and will fail validation if it's used in a task spec
+			// when the enable-api-fields feature gate is not "alpha".
+			errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "enum", config.AlphaAPIFields))
+		}
 		errs = errs.Also(p.ValidateType())
+		errs = errs.Also(p.validateEnum())
 	}
 	return errs
 }
 
+// validateEnum checks that the Enum of a ParamSpec is only used for a string param and
+// that its default value is one of the allowed values.
+func (p ParamSpec) validateEnum() *apis.FieldError {
+	if len(p.Enum) == 0 {
+		return nil
+	}
+	if p.Type != ParamTypeString {
+		return apis.ErrGeneric(fmt.Sprintf("enum is only allowed for parameters of type string but %q is of type %q", p.Name, p.Type), fmt.Sprintf("%s.enum", p.Name))
+	}
+	if p.Default != nil && !p.AllowsValue(p.Default.StringVal) {
+		return apis.ErrInvalidValue(p.Default.StringVal, fmt.Sprintf("%s.default", p.Name), fmt.Sprintf("allowed values are %s", strings.Join(p.Enum, ", ")))
+	}
+	return nil
+}
+
 // ValidateType checks that the type of a ParamSpec is allowed and its default value matches that type
 func (p ParamSpec) ValidateType() *apis.FieldError {
 	// Ensure param has a valid type.
@@ -570,6 +884,12 @@ func validateStepVariables(ctx context.Context, step Step, prefix string, vars s
 		errs = errs.Also(validateTaskVariable(v.MountPath, prefix, vars).ViaField("MountPath").ViaFieldIndex("volumeMount", i))
 		errs = errs.Also(validateTaskVariable(v.SubPath, prefix, vars).ViaField("SubPath").ViaFieldIndex("volumeMount", i))
 	}
+	for i, we := range step.When {
+		errs = errs.Also(validateTaskVariable(we.Input, prefix, vars).ViaField("input").ViaFieldIndex("when", i))
+		for _, val := range we.Values {
+			errs = errs.Also(validateTaskVariable(val, prefix, vars).ViaField("values").ViaFieldIndex("when", i))
+		}
+	}
 	return errs
 }
 
@@ -29,6 +29,8 @@ import (
 	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"knative.dev/pkg/apis"
 )
 
@@ -88,6 +90,19 @@ func TestTaskSpecValidate(t *testing.T) {
 				Image: "myotherimage",
 			}},
 		},
+	}, {
+		name: "unique step names",
+		fields: fields{
+			Steps: []v1.Step{{
+				Name:  "build",
+				Image: "myimage",
+			}, {
+				Image: "myimage",
+			}, {
+				Name:  "test",
+				Image: "myotherimage",
+			}},
+		},
 	}, {
 		name: "valid params type implied",
 		fields: fields{
@@ -255,6 +270,15 @@ func TestTaskSpecValidate(t *testing.T) {
 				hello $1`,
 			}},
 		},
+	}, {
+		name: "valid step with command and args",
+		fields: fields{
+			Steps: []v1.Step{{
+				Image:   "my-image",
+				Command: []string{"echo"},
+				Args:    []string{"hello"},
+			}},
+		},
 	}, {
 		name: "valid step with volumeMount under /tekton/home",
 		fields: fields{
@@ -279,6 +303,25 @@ func TestTaskSpecValidate(t *testing.T) {
 				MountPath:   "some/path",
 			}},
 		},
+	}, {
+		name: "valid workspaces with distinct mount paths",
+		fields: fields{
+			Steps: []v1.Step{{
+				Image: "my-image",
+				Args:  []string{"arg"},
+			}},
+			Workspaces: []v1.WorkspaceDeclaration{{
+				Name: "source",
+			}, {
+				Name:      "cache",
+				MountPath: "/cache",
+				Optional:  true,
+			}, {
+				Name:      "config",
+				MountPath: "/workspace/config-files",
+				ReadOnly:  true,
+			}},
+		},
 	}, {
 		name: "valid result",
 		fields: fields{
@@ -328,6 +371,9 @@ func TestTaskSpecValidate(t *testing.T) {
 				Name:        "MY-RESULT",
 				Type:        v1.ResultsTypeObject,
 				Description: "my great result",
+				Properties: map[string]v1.PropertySpec{
+					"url": {Type: "string"},
+				},
 			}},
 		},
 	}, {
@@ -385,6 +431,22 @@ func TestTaskSpecValidate(t *testing.T) {
 				hello "$(context.taskRun.namespace)"`,
 			}},
 		},
+	}, {
+		name: "param default referencing another param",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name:    "image",
+				Type:    v1.ParamTypeString,
+				Default: v1.NewArrayOrString("$(params.registry)/app:latest"),
+			}, {
+				Name:    "registry",
+				Type:    v1.ParamTypeString,
+				Default: v1.NewArrayOrString("gcr.io"),
+			}},
+			Steps: []v1.Step{{
+				Image: "$(params.image)",
+			}},
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -417,891 +479,1711 @@ func TestTaskSpecValidateError(t *testing.T) {
 		name          string
 		fields        fields
 		expectedError apis.FieldError
-	}{ /*{
-			name: "empty spec",
-			expectedError: apis.FieldError{
-				Message: `missing field(s)`,
-				Paths:   []string{"steps"},
-			},
-		}, {
-			name: "no step",
-			fields: fields{
-				Params: []v1.ParamSpec{{
-					Name:        "validparam",
-					Type:        v1.ParamTypeString,
-					Description: "parameter",
-					Default:     v1.NewArrayOrString("default"),
-				}},
-			},
-			expectedError: apis.FieldError{
-				Message: `missing field(s)`,
-				Paths:   []string{"steps"},
-			},
-		}, */{
-			name: "invalid param name format",
-			fields: fields{
-				Params: []v1.ParamSpec{{
-					Name:        "_validparam1",
-					Description: "valid param name format",
-				}, {
-					Name:        "valid_param2",
-					Description: "valid param name format",
-				}, {
-					Name:        "",
-					Description: "invalid param name format",
-				}, {
-					Name:        "a^b",
-					Description: "invalid param name format",
-				}, {
-					Name:        "0ab",
-					Description: "invalid param name format",
-				}, {
-					Name:        "f oo",
-					Description: "invalid param name format",
-				}},
-				Steps: validSteps,
-			},
-			expectedError: apis.FieldError{
-				Message: fmt.Sprintf("The format of following array and string variable names is invalid: %s", []string{"", "0ab", "a^b", "f oo"}),
-				Paths:   []string{"params"},
-				Details: "String/Array Names: \nMust only contain alphanumeric characters, hyphens (-), underscores (_), and dots (.)\nMust begin with a letter or an underscore (_)",
-			},
-		}, {
-			name: "invalid object param format - object param name and key name shouldn't contain dots.",
-			fields: fields{
-				Params: []v1.ParamSpec{{
-					Name:        "invalid.name1",
-					Description: "object param name contains dots",
-					Properties: map[string]v1.PropertySpec{
-						"invalid.key1": {},
-						"mykey2":       {},
-					},
-				}},
-				Steps: validSteps,
-			},
-			expectedError: apis.FieldError{
-				Message: fmt.Sprintf("Object param name and key name format is invalid: %v", map[string][]string{
-					"invalid.name1": {"invalid.key1"},
-				}),
-				Paths:   []string{"params"},
-				Details: "Object Names: \nMust only contain alphanumeric characters, hyphens (-), underscores (_) \nMust begin with a letter or an underscore (_)",
-			},
-		}, {
-			name: "duplicated param names",
-			fields: fields{
-				Params: []v1.ParamSpec{{
-					Name:        "foo",
-					Type:        v1.ParamTypeString,
-					Description: "parameter",
-					Default:     v1.NewArrayOrString("value1"),
-				}, {
-					Name:        "foo",
-					Type:        v1.ParamTypeString,
-					Description: "parameter",
-					Default:     v1.NewArrayOrString("value2"),
-				}},
-				Steps: validSteps,
-			},
-			expectedError: apis.FieldError{
-				Message: `parameter appears more than once`,
-				Paths:   []string{"params[foo]"},
-			},
-		}, {
-			name: "invalid param type",
-			fields: fields{
-				Params: []v1.ParamSpec{{
-					Name:        "validparam",
-					Type:        v1.ParamTypeString,
-					Description: "parameter",
-					Default:     v1.NewArrayOrString("default"),
-				}, {
-					Name:        "param-with-invalid-type",
-					Type:        "invalidtype",
-					Description: "invalidtypedesc",
-					Default:     v1.NewArrayOrString("default"),
-				}},
-				Steps: validSteps,
-			},
-			expectedError: apis.FieldError{
-				Message: `invalid value: invalidtype`,
-				Paths:   []string{"params.param-with-invalid-type.type"},
-			},
-		}, {
-			name: "param mismatching default/type 1",
-			fields: fields{
-				Params: []v1.ParamSpec{{
-					Name:        "task",
-					Type:        v1.ParamTypeArray,
-					Description: "param",
-					Default:     v1.NewArrayOrString("default"),
-				}},
-				Steps: validSteps,
-			},
-			expectedError: apis.FieldError{
-				Message: `"array" type does not match default value's type: "string"`,
-				Paths:   []string{"params.task.type", "params.task.default.type"},
-			},
-		}, {
-			name: "param mismatching default/type 2",
-			fields: fields{
-				Params: []v1.ParamSpec{{
-					Name:        "task",
-					Type:        v1.ParamTypeString,
-					Description: "param",
-					Default:     v1.NewArrayOrString("default", "array"),
-				}},
-				Steps: validSteps,
-			},
-			expectedError: apis.FieldError{
-				Message: `"string" type does not match default value's type: "array"`,
-				Paths:   []string{"params.task.type", "params.task.default.type"},
-			},
-		}, {
-			name: "param mismatching default/type 3",
-			fields: fields{
-				Params: []v1.ParamSpec{{
-					Name:        "task",
-					Type:        v1.ParamTypeArray,
-					Description: "param",
-					Default: v1.NewObject(map[string]string{
-						"key1": "var1",
-						"key2": "var2",
-					}),
-				}},
-				Steps: validSteps,
-			},
-			expectedError: apis.FieldError{
-				Message: `"array" type does not match default value's type: "object"`,
-				Paths:   []string{"params.task.type", "params.task.default.type"},
-			},
-		}, {
-			name: "param mismatching default/type 4",
-			fields: fields{
-				Params: []v1.ParamSpec{{
-					Name:        "task",
-					Type:        v1.ParamTypeObject,
-					Description: "param",
-					Properties:  map[string]v1.PropertySpec{"key1": {}},
-					Default:     v1.NewArrayOrString("var"),
-				}},
-				Steps: validSteps,
-			},
-			expectedError: apis.FieldError{
-				Message: `"object" type does not match default value's type: "string"`,
-				Paths:   []string{"params.task.type", "params.task.default.type"},
-			},
-		}, {
-			name: "the spec of object type parameter misses the definition of properties",
-			fields: fields{
-				Params: []v1.ParamSpec{{
-					Name:        "task",
-					Type:        v1.ParamTypeObject,
-					Description: "param",
-				}},
-				Steps: validSteps,
-			},
-			expectedError: *apis.ErrMissingField(fmt.Sprintf("params.task.properties")),
-		}, {
-			name: "PropertySpec type is set with unsupported type",
-			fields: fields{
-				Params: []v1.ParamSpec{{
-					Name:        "task",
-					Type:        v1.ParamTypeObject,
-					Description: "param",
-					Properties: map[string]v1.PropertySpec{
-						"key1": {Type: "number"},
-						"key2": {Type: "string"},
-					},
-				}},
-				Steps: validSteps,
-			},
-			expectedError: apis.FieldError{
-				Message: fmt.Sprintf("The value type specified for these keys %v is invalid", []string{"key1"}),
-				Paths:   []string{"params.task.properties"},
-			},
-		}, {
-			name: "keys defined in properties are missed in default",
-			fields: fields{
-				Params: []v1.ParamSpec{{
-					Name:        "myobjectParam",
-					Description: "param",
-					Properties: map[string]v1.PropertySpec{
-						"key1": {},
-						"key2": {},
-					},
-					Default: v1.NewObject(map[string]string{
-						"key1": "var1",
-						"key3": "var1",
-					}),
-				}},
-				Steps: validSteps,
-			},
-			expectedError: apis.FieldError{
-				Message: fmt.Sprintf("Required key(s) %s are missing in the value provider.", []string{"key2"}),
-				Paths:   []string{"myobjectParam.properties", "myobjectParam.default"},
-			},
-		}, {
-			name: "invalid step",
-			fields: fields{
-				Params: []v1.ParamSpec{{
-					Name:        "validparam",
-					Type:        v1.ParamTypeString,
-					Description: "parameter",
-					Default:     v1.NewArrayOrString("default"),
-				}},
-				Steps: []v1.Step{},
-			},
-			expectedError: apis.FieldError{
-				Message: "missing field(s)",
-				Paths:   []string{"steps"},
-			},
-		}, {
-			name: "invalid step name",
-			fields: fields{
-				Steps: invalidSteps,
-			},
-			expectedError: apis.FieldError{
-				Message: `invalid value "replaceImage"`,
-				Paths:   []string{"steps[0].name"},
-				Details: "Task step name must be a valid DNS Label, For more info refer to https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names",
-			},
-		}, {
-			name: "inexistent param variable",
-			fields: fields{
-				Steps: []v1.Step{{
-					Name:  "mystep",
-					Image: "myimage",
-					Args:  []string{"--flag=$(params.inexistent)"},
-				}},
-			},
-			expectedError: apis.FieldError{
-				Message: `non-existent variable in "--flag=$(params.inexistent)"`,
-				Paths:   []string{"steps[0].args[0]"},
-			},
-		}, {
-			name: "array used in unaccepted field",
-			fields: fields{
-				Params: []v1.ParamSpec{{
-					Name: "baz",
-					Type: v1.ParamTypeArray,
-				}, {
-					Name: "foo-is-baz",
-					Type: v1.ParamTypeArray,
-				}},
-				Steps: []v1.Step{{
-					Name:       "mystep",
-					Image:      "$(params.baz)",
-					Command:    []string{"$(params.foo-is-baz)"},
-					Args:       []string{"$(params.baz)", "middle string", "url"},
-					WorkingDir: "/foo/bar/src/",
-				}},
-			},
-			expectedError: apis.FieldError{
-				Message: `variable type invalid in "$(params.baz)"`,
-				Paths:   []string{"steps[0].image"},
-			},
-		}, {
-			name: "array star used in unaccepted field",
-			fields: fields{
-				Params: []v1.ParamSpec{{
-					Name: "baz",
-					Type: v1.ParamTypeArray,
-				}, {
-					Name: "foo-is-baz",
-					Type: v1.ParamTypeArray,
-				}},
-				Steps: []v1.Step{{
-					Name:       "mystep",
-					Image:      "$(params.baz[*])",
-					Command:    []string{"$(params.foo-is-baz)"},
-					Args:       []string{"$(params.baz)", "middle string", "url"},
-					WorkingDir: "/foo/bar/src/",
-				}},
-			},
-			expectedError: apis.FieldError{
-				Message: `variable type invalid in "$(params.baz[*])"`,
-				Paths:   []string{"steps[0].image"},
-			},
-		}, {
-			name: "array star used illegaly in script field",
-			fields: fields{
-				Params: []v1.ParamSpec{{
-					Name: "baz",
-					Type: v1.ParamTypeArray,
-				}, {
-					Name: "foo-is-baz",
-					Type: v1.ParamTypeArray,
-				}},
-				Steps: []v1.Step{
-					{
-						Script:     "$(params.baz[*])",
-						Name:       "mystep",
-						Image:      "my-image",
-						WorkingDir: "/foo/bar/src/",
-					}},
-			},
-			expectedError: apis.FieldError{
-				Message: `variable type invalid in "$(params.baz[*])"`,
-				Paths:   []string{"steps[0].script"},
-			},
-		}, {
-			name: "array not properly isolated",
-			fields: fields{
-				Params: []v1.ParamSpec{{
-					Name: "baz",
-					Type: v1.ParamTypeArray,
-				}, {
-					Name: "foo-is-baz",
-					Type: v1.ParamTypeArray,
-				}},
-				Steps: []v1.Step{{
-					Name:       "mystep",
-					Image:      "someimage",
-					Command:    []string{"$(params.foo-is-baz)"},
-					Args:       []string{"not isolated: $(params.baz)", "middle string", "url"},
-					WorkingDir: "/foo/bar/src/",
-				}},
-			},
-			expectedError: apis.FieldError{
-				Message: `variable is not properly isolated in "not isolated: $(params.baz)"`,
-				Paths:   []string{"steps[0].args[0]"},
-			},
-		}, {
-			name: "array star not properly isolated",
-			fields: fields{
-				Params: []v1.ParamSpec{{
-					Name: "baz",
-					Type: v1.ParamTypeArray,
-				}, {
-					Name: "foo-is-baz",
-					Type: v1.ParamTypeArray,
-				}},
-				Steps: []v1.Step{{
-					Name:       "mystep",
-					Image:      "someimage",
-					Command:    []string{"$(params.foo-is-baz)"},
-					Args:       []string{"not isolated: $(params.baz[*])", "middle string", "url"},
-					WorkingDir: "/foo/bar/src/",
-				}},
-			},
-			expectedError: apis.FieldError{
-				Message: `variable is not properly isolated in "not isolated: $(params.baz[*])"`,
-				Paths:   []string{"steps[0].args[0]"},
-			},
-		}, {
-			name: "inferred array not properly isolated",
-			fields: fields{
-				Params: []v1.ParamSpec{{
-					Name:    "baz",
-					Default: v1.NewArrayOrString("implied", "array", "type"),
-				}, {
-					Name:    "foo-is-baz",
-					Default: v1.NewArrayOrString("implied", "array", "type"),
-				}},
-				Steps: []v1.Step{{
-					Name:       "mystep",
-					Image:      "someimage",
-					Command:    []string{"$(params.foo-is-baz)"},
-					Args:       []string{"not isolated: $(params.baz)", "middle string", "url"},
-					WorkingDir: "/foo/bar/src/",
-				}},
-			},
-			expectedError: apis.FieldError{
-				Message: `variable is not properly isolated in "not isolated: $(params.baz)"`,
-				Paths:   []string{"steps[0].args[0]"},
-			},
-		}, {
-			name: "inferred array star not properly isolated",
-			fields: fields{
-				Params: []v1.ParamSpec{{
-					Name:    "baz",
-					Default: v1.NewArrayOrString("implied", "array", "type"),
-				}, {
-					Name:    "foo-is-baz",
-					Default: v1.NewArrayOrString("implied", "array", "type"),
-				}},
-				Steps: []v1.Step{{
-					Name:       "mystep",
-					Image:      "someimage",
-					Command:    []string{"$(params.foo-is-baz)"},
-					Args:       []string{"not isolated: $(params.baz[*])", "middle string", "url"},
-					WorkingDir: "/foo/bar/src/",
-				}},
-			},
-			expectedError: apis.FieldError{
-				Message: `variable is not properly isolated in "not isolated: $(params.baz[*])"`,
-				Paths:   []string{"steps[0].args[0]"},
-			},
-		}, {
-			name: "object used in a string field",
-			fields: fields{
-				Params: []v1.ParamSpec{{
-					Name: "gitrepo",
-					Type: v1.ParamTypeObject,
-					Properties: map[string]v1.PropertySpec{
-						"url":    {},
-						"commit": {},
-					},
-				}},
-				Steps: []v1.Step{{
-					Name:       "do-the-clone",
-					Image:      "$(params.gitrepo)",
-					Args:       []string{"echo"},
-					WorkingDir: "/foo/bar/src/",
-				}},
-			},
-			expectedError: apis.FieldError{
-				Message: `variable type invalid in "$(params.gitrepo)"`,
-				Paths:   []string{"steps[0].image"},
-			},
-		}, {
-			name: "object star used in a string field",
-			fields: fields{
-				Params: []v1.ParamSpec{{
-					Name: "gitrepo",
-					Type: v1.ParamTypeObject,
-					Properties: map[string]v1.PropertySpec{
-						"url":    {},
-						"commit": {},
-					},
-				}},
-				Steps: []v1.Step{{
-					Name:       "do-the-clone",
-					Image:      "$(params.gitrepo[*])",
-					Args:       []string{"echo"},
-					WorkingDir: "/foo/bar/src/",
-				}},
-			},
-			expectedError: apis.FieldError{
-				Message: `variable type invalid in "$(params.gitrepo[*])"`,
-				Paths:   []string{"steps[0].image"},
-			},
-		}, {
-			name: "object used in a field that can accept array type",
-			fields: fields{
-				Params: []v1.ParamSpec{{
-					Name: "gitrepo",
-					Type: v1.ParamTypeObject,
-					Properties: map[string]v1.PropertySpec{
-						"url":    {},
-						"commit": {},
-					},
-				}},
-				Steps: []v1.Step{{
-					Name:       "do-the-clone",
-					Image:      "myimage",
-					Args:       []string{"$(params.gitrepo)"},
-					WorkingDir: "/foo/bar/src/",
-				}},
-			},
-			expectedError: apis.FieldError{
-				Message: `variable type invalid in "$(params.gitrepo)"`,
-				Paths:   []string{"steps[0].args[0]"},
-			},
-		}, {
-			name: "object star used in a field that can accept array type",
-			fields: fields{
-				Params: []v1.ParamSpec{{
-					Name: "gitrepo",
-					Type: v1.ParamTypeObject,
-					Properties: map[string]v1.PropertySpec{
-						"url":    {},
-						"commit": {},
-					},
-				}},
-				Steps: []v1.Step{{
-					Name:       "do-the-clone",
-					Image:      "some-git-image",
-					Args:       []string{"$(params.gitrepo[*])"},
-					WorkingDir: "/foo/bar/src/",
-				}},
-			},
-			expectedError: apis.FieldError{
-				Message: `variable type invalid in "$(params.gitrepo[*])"`,
-				Paths:   []string{"steps[0].args[0]"},
-			},
-		}, {
-			name: "Inexistent param variable in volumeMount with existing",
-			fields: fields{
-				Params: []v1.ParamSpec{
-					{
-						Name:        "foo",
-						Description: "param",
-						Default:     v1.NewArrayOrString("default"),
-					},
+	}{{
+		name: "empty spec",
+		expectedError: apis.FieldError{
+			Message: `missing field(s)`,
+			Paths:   []string{"steps"},
+		},
+	}, {
+		name: "no step",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name:        "validparam",
+				Type:        v1.ParamTypeString,
+				Description: "parameter",
+				Default:     v1.NewArrayOrString("default"),
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `missing field(s)`,
+			Paths:   []string{"steps"},
+		},
+	}, {
+		name: "invalid param name format",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name:        "_validparam1",
+				Description: "valid param name format",
+			}, {
+				Name:        "valid_param2",
+				Description: "valid param name format",
+			}, {
+				Name:        "",
+				Description: "invalid param name format",
+			}, {
+				Name:        "a^b",
+				Description: "invalid param name format",
+			}, {
+				Name:        "0ab",
+				Description: "invalid param name format",
+			}, {
+				Name:        "f oo",
+				Description: "invalid param name format",
+			}},
+			Steps: validSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: fmt.Sprintf("The format of following array and string variable names is invalid: %s", []string{"", "0ab", "a^b", "f oo"}),
+			Paths:   []string{"params"},
+			Details: "String/Array Names: \nMust only contain alphanumeric characters, hyphens (-), underscores (_), and dots (.)\nMust begin with a letter or an underscore (_)",
+		},
+	}, {
+		name: "invalid object param format - object param name and key name shouldn't contain dots.",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name:        "invalid.name1",
+				Description: "object param name contains dots",
+				Properties: map[string]v1.PropertySpec{
+					"invalid.key1": {},
+					"mykey2":       {},
 				},
-				Steps: []v1.Step{{
-					Name:  "mystep",
-					Image: "myimage",
-					VolumeMounts: []corev1.VolumeMount{{
-						Name: "$(params.inexistent)-foo",
-					}},
-				}},
-			},
-			expectedError: apis.FieldError{
-				Message: `non-existent variable in "$(params.inexistent)-foo"`,
-				Paths:   []string{"steps[0].volumeMount[0].name"},
-			},
-		}, {
-			name: "Inexistent param variable with existing",
-			fields: fields{
-				Params: []v1.ParamSpec{{
-					Name:        "foo",
-					Description: "param",
-					Default:     v1.NewArrayOrString("default"),
-				}},
-				Steps: []v1.Step{{
-					Name:  "mystep",
-					Image: "myimage",
-					Args:  []string{"$(params.foo) && $(params.inexistent)"},
-				}},
-			},
-			expectedError: apis.FieldError{
-				Message: `non-existent variable in "$(params.foo) && $(params.inexistent)"`,
-				Paths:   []string{"steps[0].args[0]"},
-			},
-		}, {
-			name: "Multiple volumes with same name",
-			fields: fields{
-				Steps: validSteps,
-				Volumes: []corev1.Volume{{
-					Name: "workspace",
-				}, {
-					Name: "workspace",
-				}},
-			},
-			expectedError: apis.FieldError{
-				Message: `multiple volumes with same name "workspace"`,
-				Paths:   []string{"volumes[1].name"},
-			},
-		}, {
-			name: "step with script and command",
-			fields: fields{
-				Steps: []v1.Step{{
-					Image:   "myimage",
-					Command: []string{"command"},
-					Script:  "script",
-				}},
-			},
-			expectedError: apis.FieldError{
-				Message: "script cannot be used with command",
-				Paths:   []string{"steps[0].script"},
-			},
-		}, {
-			name: "step volume mounts under /tekton/",
-			fields: fields{
-				Steps: []v1.Step{{
-					Image: "myimage",
-					VolumeMounts: []corev1.VolumeMount{{
-						Name:      "foo",
-						MountPath: "/tekton/foo",
-					}},
-				}},
-			},
-			expectedError: apis.FieldError{
-				Message: `volumeMount cannot be mounted under /tekton/ (volumeMount "foo" mounted at "/tekton/foo")`,
-				Paths:   []string{"steps[0].volumeMounts[0].mountPath"},
-			},
-		}, {
-			name: "step volume mount name starts with tekton-internal-",
-			fields: fields{
-				Steps: []v1.Step{{
-					Image: "myimage",
-					VolumeMounts: []corev1.VolumeMount{{
-						Name:      "tekton-internal-foo",
-						MountPath: "/this/is/fine",
-					}},
-				}},
-			},
-			expectedError: apis.FieldError{
-				Message: `volumeMount name "tekton-internal-foo" cannot start with "tekton-internal-"`,
-				Paths:   []string{"steps[0].volumeMounts[0].name"},
-			},
-		}, {
-			name: "declared workspaces names are not unique",
-			fields: fields{
-				Steps: validSteps,
-				Workspaces: []v1.WorkspaceDeclaration{{
-					Name:      "same-workspace",
-					MountPath: "/foo",
-				}, {
-					Name:      "same-workspace",
-					MountPath: "/bar",
+			}},
+			Steps: validSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: fmt.Sprintf("Object param name and key name format is invalid: %v", map[string][]string{
+				"invalid.name1": {"invalid.key1"},
+			}),
+			Paths:   []string{"params"},
+			Details: "Object Names: \nMust only contain alphanumeric characters, hyphens (-), underscores (_) \nMust begin with a letter or an underscore (_)",
+		},
+	}, {
+		name: "duplicated param names",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name:        "foo",
+				Type:        v1.ParamTypeString,
+				Description: "parameter",
+				Default:     v1.NewArrayOrString("value1"),
+			}, {
+				Name:        "foo",
+				Type:        v1.ParamTypeString,
+				Description: "parameter",
+				Default:     v1.NewArrayOrString("value2"),
+			}},
+			Steps: validSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: `parameter appears more than once`,
+			Paths:   []string{"params[foo]"},
+		},
+	}, {
+		name: "invalid param type",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name:        "validparam",
+				Type:        v1.ParamTypeString,
+				Description: "parameter",
+				Default:     v1.NewArrayOrString("default"),
+			}, {
+				Name:        "param-with-invalid-type",
+				Type:        "invalidtype",
+				Description: "invalidtypedesc",
+				Default:     v1.NewArrayOrString("default"),
+			}},
+			Steps: validSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: invalidtype`,
+			Paths:   []string{"params.param-with-invalid-type.type"},
+		},
+	}, {
+		name: "param mismatching default/type 1",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name:        "task",
+				Type:        v1.ParamTypeArray,
+				Description: "param",
+				Default:     v1.NewArrayOrString("default"),
+			}},
+			Steps: validSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: `"array" type does not match default value's type: "string"`,
+			Paths:   []string{"params.task.type", "params.task.default.type"},
+		},
+	}, {
+		name: "param mismatching default/type 2",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name:        "task",
+				Type:        v1.ParamTypeString,
+				Description: "param",
+				Default:     v1.NewArrayOrString("default", "array"),
+			}},
+			Steps: validSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: `"string" type does not match default value's type: "array"`,
+			Paths:   []string{"params.task.type", "params.task.default.type"},
+		},
+	}, {
+		name: "param mismatching default/type 3",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name:        "task",
+				Type:        v1.ParamTypeArray,
+				Description: "param",
+				Default: v1.NewObject(map[string]string{
+					"key1": "var1",
+					"key2": "var2",
+				}),
+			}},
+			Steps: validSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: `"array" type does not match default value's type: "object"`,
+			Paths:   []string{"params.task.type", "params.task.default.type"},
+		},
+	}, {
+		name: "param mismatching default/type 4",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name:        "task",
+				Type:        v1.ParamTypeObject,
+				Description: "param",
+				Properties:  map[string]v1.PropertySpec{"key1": {}},
+				Default:     v1.NewArrayOrString("var"),
+			}},
+			Steps: validSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: `"object" type does not match default value's type: "string"`,
+			Paths:   []string{"params.task.type", "params.task.default.type"},
+		},
+	}, {
+		name: "the spec of object type parameter misses the definition of properties",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name:        "task",
+				Type:        v1.ParamTypeObject,
+				Description: "param",
+			}},
+			Steps: validSteps,
+		},
+		expectedError: *apis.ErrMissingField(fmt.Sprintf("params.task.properties")),
+	}, {
+		name: "PropertySpec type is set with unsupported type",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name:        "task",
+				Type:        v1.ParamTypeObject,
+				Description: "param",
+				Properties: map[string]v1.PropertySpec{
+					"key1": {Type: "number"},
+					"key2": {Type: "string"},
+				},
+			}},
+			Steps: validSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: fmt.Sprintf("The value type specified for these keys %v is invalid", []string{"key1"}),
+			Paths:   []string{"params.task.properties"},
+		},
+	}, {
+		name: "keys defined in properties are missed in default",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name:        "myobjectParam",
+				Description: "param",
+				Properties: map[string]v1.PropertySpec{
+					"key1": {},
+					"key2": {},
+				},
+				Default: v1.NewObject(map[string]string{
+					"key1": "var1",
+					"key3": "var1",
+				}),
+			}},
+			Steps: validSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: fmt.Sprintf("Required key(s) %s are missing in the value provider.", []string{"key2"}),
+			Paths:   []string{"myobjectParam.properties", "myobjectParam.default"},
+		},
+	}, {
+		name: "invalid step",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name:        "validparam",
+				Type:        v1.ParamTypeString,
+				Description: "parameter",
+				Default:     v1.NewArrayOrString("default"),
+			}},
+			Steps: []v1.Step{},
+		},
+		expectedError: apis.FieldError{
+			Message: "missing field(s)",
+			Paths:   []string{"steps"},
+		},
+	}, {
+		name: "invalid step name",
+		fields: fields{
+			Steps: invalidSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value "replaceImage"`,
+			Paths:   []string{"steps[0].name"},
+			Details: "Task step name must be a valid DNS Label, For more info refer to https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names",
+		},
+	}, {
+		name: "duplicate step names",
+		fields: fields{
+			Steps: []v1.Step{{
+				Name:  "build",
+				Image: "myimage",
+			}, {
+				Name:  "test",
+				Image: "myimage",
+			}, {
+				Name:  "build",
+				Image: "myotherimage",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: build`,
+			Paths:   []string{"steps[2].name"},
+		},
+	}, {
+		name: "inexistent param variable",
+		fields: fields{
+			Steps: []v1.Step{{
+				Name:  "mystep",
+				Image: "myimage",
+				Args:  []string{"--flag=$(params.inexistent)"},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `non-existent variable in "--flag=$(params.inexistent)"`,
+			Paths:   []string{"steps[0].args[0]"},
+		},
+	}, {
+		name: "array used in unaccepted field",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name: "baz",
+				Type: v1.ParamTypeArray,
+			}, {
+				Name: "foo-is-baz",
+				Type: v1.ParamTypeArray,
+			}},
+			Steps: []v1.Step{{
+				Name:       "mystep",
+				Image:      "$(params.baz)",
+				Command:    []string{"$(params.foo-is-baz)"},
+				Args:       []string{"$(params.baz)", "middle string", "url"},
+				WorkingDir: "/foo/bar/src/",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `variable type invalid in "$(params.baz)"`,
+			Paths:   []string{"steps[0].image"},
+		},
+	}, {
+		name: "array star used in unaccepted field",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name: "baz",
+				Type: v1.ParamTypeArray,
+			}, {
+				Name: "foo-is-baz",
+				Type: v1.ParamTypeArray,
+			}},
+			Steps: []v1.Step{{
+				Name:       "mystep",
+				Image:      "$(params.baz[*])",
+				Command:    []string{"$(params.foo-is-baz)"},
+				Args:       []string{"$(params.baz)", "middle string", "url"},
+				WorkingDir: "/foo/bar/src/",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `variable type invalid in "$(params.baz[*])"`,
+			Paths:   []string{"steps[0].image"},
+		},
+	}, {
+		name: "array star used illegaly in script field",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name: "baz",
+				Type: v1.ParamTypeArray,
+			}, {
+				Name: "foo-is-baz",
+				Type: v1.ParamTypeArray,
+			}},
+			Steps: []v1.Step{
+				{
+					Script:     "$(params.baz[*])",
+					Name:       "mystep",
+					Image:      "my-image",
+					WorkingDir: "/foo/bar/src/",
+				}},
+		},
+		expectedError: apis.FieldError{
+			Message: `variable type invalid in "$(params.baz[*])"`,
+			Paths:   []string{"steps[0].script"},
+		},
+	}, {
+		name: "array not properly isolated",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name: "baz",
+				Type: v1.ParamTypeArray,
+			}, {
+				Name: "foo-is-baz",
+				Type: v1.ParamTypeArray,
+			}},
+			Steps: []v1.Step{{
+				Name:       "mystep",
+				Image:      "someimage",
+				Command:    []string{"$(params.foo-is-baz)"},
+				Args:       []string{"not isolated: $(params.baz)", "middle string", "url"},
+				WorkingDir: "/foo/bar/src/",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `variable is not properly isolated in "not isolated: $(params.baz)"`,
+			Paths:   []string{"steps[0].args[0]"},
+		},
+	}, {
+		name: "array star not properly isolated",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name: "baz",
+				Type: v1.ParamTypeArray,
+			}, {
+				Name: "foo-is-baz",
+				Type: v1.ParamTypeArray,
+			}},
+			Steps: []v1.Step{{
+				Name:       "mystep",
+				Image:      "someimage",
+				Command:    []string{"$(params.foo-is-baz)"},
+				Args:       []string{"not isolated: $(params.baz[*])", "middle string", "url"},
+				WorkingDir: "/foo/bar/src/",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `variable is not properly isolated in "not isolated: $(params.baz[*])"`,
+			Paths:   []string{"steps[0].args[0]"},
+		},
+	}, {
+		name: "inferred array not properly isolated",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name:    "baz",
+				Default: v1.NewArrayOrString("implied", "array", "type"),
+			}, {
+				Name:    "foo-is-baz",
+				Default: v1.NewArrayOrString("implied", "array", "type"),
+			}},
+			Steps: []v1.Step{{
+				Name:       "mystep",
+				Image:      "someimage",
+				Command:    []string{"$(params.foo-is-baz)"},
+				Args:       []string{"not isolated: $(params.baz)", "middle string", "url"},
+				WorkingDir: "/foo/bar/src/",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `variable is not properly isolated in "not isolated: $(params.baz)"`,
+			Paths:   []string{"steps[0].args[0]"},
+		},
+	}, {
+		name: "inferred array star not properly isolated",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name:    "baz",
+				Default: v1.NewArrayOrString("implied", "array", "type"),
+			}, {
+				Name:    "foo-is-baz",
+				Default: v1.NewArrayOrString("implied", "array", "type"),
+			}},
+			Steps: []v1.Step{{
+				Name:       "mystep",
+				Image:      "someimage",
+				Command:    []string{"$(params.foo-is-baz)"},
+				Args:       []string{"not isolated: $(params.baz[*])", "middle string", "url"},
+				WorkingDir: "/foo/bar/src/",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `variable is not properly isolated in "not isolated: $(params.baz[*])"`,
+			Paths:   []string{"steps[0].args[0]"},
+		},
+	}, {
+		name: "object used in a string field",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name: "gitrepo",
+				Type: v1.ParamTypeObject,
+				Properties: map[string]v1.PropertySpec{
+					"url":    {},
+					"commit": {},
+				},
+			}},
+			Steps: []v1.Step{{
+				Name:       "do-the-clone",
+				Image:      "$(params.gitrepo)",
+				Args:       []string{"echo"},
+				WorkingDir: "/foo/bar/src/",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `variable type invalid in "$(params.gitrepo)"`,
+			Paths:   []string{"steps[0].image"},
+		},
+	}, {
+		name: "object star used in a string field",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name: "gitrepo",
+				Type: v1.ParamTypeObject,
+				Properties: map[string]v1.PropertySpec{
+					"url":    {},
+					"commit": {},
+				},
+			}},
+			Steps: []v1.Step{{
+				Name:       "do-the-clone",
+				Image:      "$(params.gitrepo[*])",
+				Args:       []string{"echo"},
+				WorkingDir: "/foo/bar/src/",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `variable type invalid in "$(params.gitrepo[*])"`,
+			Paths:   []string{"steps[0].image"},
+		},
+	}, {
+		name: "object used in a field that can accept array type",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name: "gitrepo",
+				Type: v1.ParamTypeObject,
+				Properties: map[string]v1.PropertySpec{
+					"url":    {},
+					"commit": {},
+				},
+			}},
+			Steps: []v1.Step{{
+				Name:       "do-the-clone",
+				Image:      "myimage",
+				Args:       []string{"$(params.gitrepo)"},
+				WorkingDir: "/foo/bar/src/",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `variable type invalid in "$(params.gitrepo)"`,
+			Paths:   []string{"steps[0].args[0]"},
+		},
+	}, {
+		name: "object star used in a field that can accept array type",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name: "gitrepo",
+				Type: v1.ParamTypeObject,
+				Properties: map[string]v1.PropertySpec{
+					"url":    {},
+					"commit": {},
+				},
+			}},
+			Steps: []v1.Step{{
+				Name:       "do-the-clone",
+				Image:      "some-git-image",
+				Args:       []string{"$(params.gitrepo[*])"},
+				WorkingDir: "/foo/bar/src/",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `variable type invalid in "$(params.gitrepo[*])"`,
+			Paths:   []string{"steps[0].args[0]"},
+		},
+	}, {
+		name: "Inexistent param variable in volumeMount with existing",
+		fields: fields{
+			Params: []v1.ParamSpec{
+				{
+					Name:        "foo",
+					Description: "param",
+					Default:     v1.NewArrayOrString("default"),
+				},
+			},
+			Steps: []v1.Step{{
+				Name:  "mystep",
+				Image: "myimage",
+				VolumeMounts: []corev1.VolumeMount{{
+					Name: "$(params.inexistent)-foo",
+				}},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `non-existent variable in "$(params.inexistent)-foo"`,
+			Paths:   []string{"steps[0].volumeMount[0].name"},
+		},
+	}, {
+		name: "Inexistent param variable with existing",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name:        "foo",
+				Description: "param",
+				Default:     v1.NewArrayOrString("default"),
+			}},
+			Steps: []v1.Step{{
+				Name:  "mystep",
+				Image: "myimage",
+				Args:  []string{"$(params.foo) && $(params.inexistent)"},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `non-existent variable in "$(params.foo) && $(params.inexistent)"`,
+			Paths:   []string{"steps[0].args[0]"},
+		},
+	}, {
+		name: "Multiple volumes with same name",
+		fields: fields{
+			Steps: validSteps,
+			Volumes: []corev1.Volume{{
+				Name: "workspace",
+			}, {
+				Name: "workspace",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `multiple volumes with same name "workspace"`,
+			Paths:   []string{"volumes[1].name"},
+		},
+	}, {
+		name: "step with script and command",
+		fields: fields{
+			Steps: []v1.Step{{
+				Image:   "myimage",
+				Command: []string{"command"},
+				Script:  "script",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: "script cannot be used with command",
+			Paths:   []string{"steps[0].script"},
+		},
+	}, {
+		name: "step with script, command and args",
+		fields: fields{
+			Steps: []v1.Step{{
+				Image:   "myimage",
+				Command: []string{"command"},
+				Args:    []string{"arg"},
+				Script:  "script",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: "script cannot be used with command",
+			Paths:   []string{"steps[0].script"},
+		},
+	}, {
+		name: "step with script and command from step template",
+		fields: fields{
+			StepTemplate: &v1.StepTemplate{
+				Command: []string{"command"},
+			},
+			Steps: []v1.Step{{
+				Image:  "myimage",
+				Script: "script",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: "script cannot be used with command",
+			Paths:   []string{"steps[0].script"},
+		},
+	}, {
+		name: "step volume mounts under /tekton/",
+		fields: fields{
+			Steps: []v1.Step{{
+				Image: "myimage",
+				VolumeMounts: []corev1.VolumeMount{{
+					Name:      "foo",
+					MountPath: "/tekton/foo",
+				}},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `volumeMount cannot be mounted under /tekton/ (volumeMount "foo" mounted at "/tekton/foo")`,
+			Paths:   []string{"steps[0].volumeMounts[0].mountPath"},
+		},
+	}, {
+		name: "step volume mount name starts with tekton-internal-",
+		fields: fields{
+			Steps: []v1.Step{{
+				Image: "myimage",
+				VolumeMounts: []corev1.VolumeMount{{
+					Name:      "tekton-internal-foo",
+					MountPath: "/this/is/fine",
+				}},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `volumeMount name "tekton-internal-foo" cannot start with "tekton-internal-"`,
+			Paths:   []string{"steps[0].volumeMounts[0].name"},
+		},
+	}, {
+		name: "declared workspaces names are not unique",
+		fields: fields{
+			Steps: validSteps,
+			Workspaces: []v1.WorkspaceDeclaration{{
+				Name:      "same-workspace",
+				MountPath: "/foo",
+			}, {
+				Name:      "same-workspace",
+				MountPath: "/bar",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: "workspace name \"same-workspace\" must be unique",
+			Paths:   []string{"workspaces[1].name"},
+		},
+	}, {
+		name: "declared workspaces clash with each other",
+		fields: fields{
+			Steps: validSteps,
+			Workspaces: []v1.WorkspaceDeclaration{{
+				Name:      "some-workspace",
+				MountPath: "/foo",
+			}, {
+				Name:      "another-workspace",
+				MountPath: "/foo",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: "workspace mount path \"/foo\" must be unique",
+			Paths:   []string{"workspaces[1].mountpath"},
+		},
+	}, {
+		name: "declared mount path clashes with another workspace's default mount path",
+		fields: fields{
+			Steps: validSteps,
+			Workspaces: []v1.WorkspaceDeclaration{{
+				Name:      "some-workspace",
+				MountPath: "/workspace/another-workspace/",
+			}, {
+				Name: "another-workspace",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: "workspace mount path \"/workspace/another-workspace\" must be unique",
+			Paths:   []string{"workspaces[1].mountpath"},
+		},
+	}, {
+		name: "workspace mount path already in volumeMounts",
+		fields: fields{
+			Steps: []v1.Step{{
+				Image:   "myimage",
+				Command: []string{"command"},
+				VolumeMounts: []corev1.VolumeMount{{
+					Name:      "my-mount",
+					MountPath: "/foo",
+				}},
+			}},
+			Workspaces: []v1.WorkspaceDeclaration{{
+				Name:      "some-workspace",
+				MountPath: "/foo",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: "workspace mount path \"/foo\" must be unique",
+			Paths:   []string{"workspaces[0].mountpath"},
+		},
+	}, {
+		name: "workspace default mount path already in volumeMounts",
+		fields: fields{
+			Steps: []v1.Step{{
+				Image:   "myimage",
+				Command: []string{"command"},
+				VolumeMounts: []corev1.VolumeMount{{
+					Name:      "my-mount",
+					MountPath: "/workspace/some-workspace/",
+				}},
+			}},
+			Workspaces: []v1.WorkspaceDeclaration{{
+				Name: "some-workspace",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: "workspace mount path \"/workspace/some-workspace\" must be unique",
+			Paths:   []string{"workspaces[0].mountpath"},
+		},
+	}, {
+		name: "workspace mount path already in stepTemplate",
+		fields: fields{
+			StepTemplate: &v1.StepTemplate{
+				VolumeMounts: []corev1.VolumeMount{{
+					Name:      "my-mount",
+					MountPath: "/foo",
+				}},
+			},
+			Steps: validSteps,
+			Workspaces: []v1.WorkspaceDeclaration{{
+				Name:      "some-workspace",
+				MountPath: "/foo",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: "workspace mount path \"/foo\" must be unique",
+			Paths:   []string{"workspaces[0].mountpath"},
+		},
+	}, {
+		name: "workspace default mount path already in stepTemplate",
+		fields: fields{
+			StepTemplate: &v1.StepTemplate{
+				VolumeMounts: []corev1.VolumeMount{{
+					Name:      "my-mount",
+					MountPath: "/workspace/some-workspace",
+				}},
+			},
+			Steps: validSteps,
+			Workspaces: []v1.WorkspaceDeclaration{{
+				Name: "some-workspace",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: "workspace mount path \"/workspace/some-workspace\" must be unique",
+			Paths:   []string{"workspaces[0].mountpath"},
+		},
+	}, {
+		name: "result name not validate",
+		fields: fields{
+			Steps: validSteps,
+			Results: []v1.TaskResult{{
+				Name:        "MY^RESULT",
+				Description: "my great result",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid key name "MY^RESULT"`,
+			Paths:   []string{"results[0].name"},
+			Details: "Name must consist of alphanumeric characters, '-', '_', and must start and end with an alphanumeric character (e.g. 'MyName',  or 'my-name',  or 'my_name', regex used for validation is '^([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]$')",
+		},
+	}, {
+		name: "result type not validate",
+		fields: fields{
+			Steps: validSteps,
+			Results: []v1.TaskResult{{
+				Name:        "MY-RESULT",
+				Type:        "wrong",
+				Description: "my great result",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: wrong`,
+			Paths:   []string{"results[0].type"},
+			Details: "type must be string",
+		},
+	}, {
+		name: "context not validate",
+		fields: fields{
+			Steps: []v1.Step{{
+				Image: "my-image",
+				Args:  []string{"arg"},
+				Script: `
+				#!/usr/bin/env  bash
+				hello "$(context.task.missing)"`,
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `non-existent variable in "\n\t\t\t\t#!/usr/bin/env  bash\n\t\t\t\thello \"$(context.task.missing)\""`,
+			Paths:   []string{"steps[0].script"},
+		},
+	}, {
+		name: "stdout captured into a non-string result",
+		fields: fields{
+			Steps: []v1.Step{{
+				Image:        "my-image",
+				StdoutConfig: &v1.StepOutputConfig{Path: "$(results.digests.path)"},
+			}},
+			Results: []v1.TaskResult{{
+				Name: "digests",
+				Type: v1.ResultsTypeArray,
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: $(results.digests.path)`,
+			Paths:   []string{"steps[0].stdoutConfig.path"},
+			Details: `stdout can only be captured into a string result, but result "digests" is of type array`,
+		},
+	}, {
+		name: "param defaults referencing each other",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name:    "a",
+				Type:    v1.ParamTypeString,
+				Default: v1.NewArrayOrString("$(params.b)"),
+			}, {
+				Name:    "b",
+				Type:    v1.ParamTypeString,
+				Default: v1.NewArrayOrString("prefix-$(params.a)"),
+			}},
+			Steps: validSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: "param defaults reference each other in a cycle: a -> b -> a",
+			Paths:   []string{"params"},
+		},
+	}, {
+		name: "param default with a malformed reference",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name:    "a",
+				Type:    v1.ParamTypeString,
+				Default: v1.NewArrayOrString("$(params.b.c.d)"),
+			}, {
+				Name: "b",
+				Type: v1.ParamTypeString,
+			}},
+			Steps: validSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: `Invalid referencing of parameters in "$(params.b.c.d)"! Only two dot-separated components after the prefix "params" are allowed.`,
+			Paths:   []string{"params[a].default"},
+		},
+	}, {
+		name: "negative timeout string",
+		fields: fields{
+			Steps: []v1.Step{{
+				Timeout: &metav1.Duration{Duration: -10 * time.Second},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: "invalid value: -10s",
+			Paths:   []string{"steps[0].negative timeout"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Params:       tt.fields.Params,
+				Steps:        tt.fields.Steps,
+				Volumes:      tt.fields.Volumes,
+				StepTemplate: tt.fields.StepTemplate,
+				Workspaces:   tt.fields.Workspaces,
+				Results:      tt.fields.Results,
+			}
+			ctx := config.EnableAlphaAPIFields(context.Background())
+			ts.SetDefaults(ctx)
+			err := ts.Validate(ctx)
+			if err == nil {
+				t.Fatalf("Expected an error, got nothing for %v", ts)
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestStepAndSidecarWorkspaces(t *testing.T) {
+	type fields struct {
+		Steps      []v1.Step
+		Sidecars   []v1.Sidecar
+		Workspaces []v1.WorkspaceDeclaration
+	}
+	tests := []struct {
+		name   string
+		fields fields
+	}{{
+		name: "valid step workspace usage",
+		fields: fields{
+			Steps: []v1.Step{{
+				Image: "my-image",
+				Args:  []string{"arg"},
+				Workspaces: []v1.WorkspaceUsage{{
+					Name:      "foo-workspace",
+					MountPath: "/a/custom/mountpath",
 				}},
-			},
-			expectedError: apis.FieldError{
-				Message: "workspace name \"same-workspace\" must be unique",
-				Paths:   []string{"workspaces[1].name"},
-			},
+			}},
+			Workspaces: []v1.WorkspaceDeclaration{{
+				Name:        "foo-workspace",
+				Description: "my great workspace",
+				MountPath:   "some/path",
+			}},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Steps:      tt.fields.Steps,
+				Sidecars:   tt.fields.Sidecars,
+				Workspaces: tt.fields.Workspaces,
+			}
+			ctx := config.EnableAlphaAPIFields(context.Background())
+			ts.SetDefaults(ctx)
+			if err := ts.Validate(ctx); err != nil {
+				t.Errorf("TaskSpec.Validate() = %v", err)
+			}
+		})
+	}
+}
+
+func TestSidecarNameErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		sidecars      []v1.Sidecar
+		expectedError apis.FieldError
+	}{{
+		name: "duplicate sidecar names",
+		sidecars: []v1.Sidecar{{
+			Name:  "registry",
+			Image: "registry",
 		}, {
-			name: "declared workspaces clash with each other",
-			fields: fields{
-				Steps: validSteps,
-				Workspaces: []v1.WorkspaceDeclaration{{
-					Name:      "some-workspace",
-					MountPath: "/foo",
-				}, {
-					Name:      "another-workspace",
-					MountPath: "/foo",
-				}},
-			},
-			expectedError: apis.FieldError{
-				Message: "workspace mount path \"/foo\" must be unique",
-				Paths:   []string{"workspaces[1].mountpath"},
-			},
+			Image: "database",
 		}, {
-			name: "workspace mount path already in volumeMounts",
-			fields: fields{
+			Name:  "registry",
+			Image: "registry",
+		}},
+		expectedError: apis.FieldError{
+			Message: `multiple sidecars with same name "registry"`,
+			Paths:   []string{"sidecars[2].name"},
+		},
+	}, {
+		name: "sidecar named after a step",
+		sidecars: []v1.Sidecar{{
+			Name:  "build",
+			Image: "registry",
+		}},
+		expectedError: apis.FieldError{
+			Message: `sidecar name "build" is also the name of a step`,
+			Paths:   []string{"sidecars[0].name"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
 				Steps: []v1.Step{{
-					Image:   "myimage",
-					Command: []string{"command"},
-					VolumeMounts: []corev1.VolumeMount{{
-						Name:      "my-mount",
-						MountPath: "/foo",
-					}},
+					Name:  "build",
+					Image: "golang",
 				}},
-				Workspaces: []v1.WorkspaceDeclaration{{
-					Name:      "some-workspace",
-					MountPath: "/foo",
+				Sidecars: tt.sidecars,
+			}
+			ctx := context.Background()
+			ts.SetDefaults(ctx)
+			err := ts.Validate(ctx)
+			if err == nil {
+				t.Fatalf("Expected an error, got nothing for %v", ts)
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestStepAndSidecarWorkspacesErrors(t *testing.T) {
+	type fields struct {
+		Steps    []v1.Step
+		Sidecars []v1.Sidecar
+	}
+	tests := []struct {
+		name          string
+		fields        fields
+		expectedError apis.FieldError
+	}{{
+		name: "step workspace that refers to non-existent workspace declaration fails",
+		fields: fields{
+			Steps: []v1.Step{{
+				Image: "foo",
+				Workspaces: []v1.WorkspaceUsage{{
+					Name: "foo",
+				}},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `undefined workspace "foo"`,
+			Paths:   []string{"steps[0].workspaces[0].name"},
+		},
+	}, {
+		name: "sidecar workspace that refers to non-existent workspace declaration fails",
+		fields: fields{
+			Steps: []v1.Step{{
+				Image: "foo",
+			}},
+			Sidecars: []v1.Sidecar{{
+				Image: "foo",
+				Workspaces: []v1.WorkspaceUsage{{
+					Name: "foo",
 				}},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `undefined workspace "foo"`,
+			Paths:   []string{"sidecars[0].workspaces[0].name"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Steps:    tt.fields.Steps,
+				Sidecars: tt.fields.Sidecars,
+			}
+
+			ctx := config.EnableAlphaAPIFields(context.Background())
+			ts.SetDefaults(ctx)
+			err := ts.Validate(ctx)
+			if err == nil {
+				t.Fatalf("Expected an error, got nothing for %v", ts)
+			}
+
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestSidecarProbes(t *testing.T) {
+	tests := []struct {
+		name     string
+		sidecars []v1.Sidecar
+	}{{
+		name: "sidecar without probes",
+		sidecars: []v1.Sidecar{{
+			Image: "my-image",
+		}},
+	}, {
+		name: "sidecar with tcp readiness probe on a port number",
+		sidecars: []v1.Sidecar{{
+			Image: "my-image",
+			ReadinessProbe: &corev1.Probe{
+				ProbeHandler: corev1.ProbeHandler{
+					TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt(8080)},
+				},
 			},
-			expectedError: apis.FieldError{
-				Message: "workspace mount path \"/foo\" must be unique",
-				Paths:   []string{"workspaces[0].mountpath"},
+		}},
+	}, {
+		name: "sidecar with http startup probe on a named port",
+		sidecars: []v1.Sidecar{{
+			Image: "my-image",
+			Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: 8080}},
+			StartupProbe: &corev1.Probe{
+				ProbeHandler: corev1.ProbeHandler{
+					HTTPGet: &corev1.HTTPGetAction{Path: "/healthz", Port: intstr.FromString("http")},
+				},
 			},
-		}, {
-			name: "workspace default mount path already in volumeMounts",
-			fields: fields{
+		}},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Steps:    validSteps,
+				Sidecars: tt.sidecars,
+			}
+			if err := ts.Validate(context.Background()); err != nil {
+				t.Errorf("TaskSpec.Validate() = %v", err)
+			}
+		})
+	}
+}
+
+func TestSidecarProbesErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		sidecars      []v1.Sidecar
+		expectedError apis.FieldError
+	}{{
+		name: "readiness probe on an undeclared port name",
+		sidecars: []v1.Sidecar{{
+			Image: "my-image",
+			Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: 8080}},
+			ReadinessProbe: &corev1.Probe{
+				ProbeHandler: corev1.ProbeHandler{
+					TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromString("grpc")},
+				},
+			},
+		}},
+		expectedError: apis.FieldError{
+			Message: "invalid value: grpc",
+			Paths:   []string{"sidecars[0].readinessProbe.tcpSocket.port"},
+			Details: "port name must match a port declared by the container",
+		},
+	}, {
+		name: "liveness probe on an invalid port number",
+		sidecars: []v1.Sidecar{{
+			Image: "my-image",
+			LivenessProbe: &corev1.Probe{
+				ProbeHandler: corev1.ProbeHandler{
+					GRPC: &corev1.GRPCAction{Port: 0},
+				},
+			},
+		}},
+		expectedError: apis.FieldError{
+			Message: "invalid value: 0",
+			Paths:   []string{"sidecars[0].livenessProbe.grpc.port"},
+			Details: "must be between 1 and 65535, inclusive",
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Steps:    validSteps,
+				Sidecars: tt.sidecars,
+			}
+			err := ts.Validate(context.Background())
+			if err == nil {
+				t.Fatalf("Expected an error, got nothing for %v", ts)
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestStepOnError(t *testing.T) {
+	tests := []struct {
+		name          string
+		steps         []v1.Step
+		expectedError *apis.FieldError
+	}{{
+		name: "valid step - valid onError usage - set to continue - alpha API",
+		steps: []v1.Step{{
+			OnError: "continue",
+			Image:   "image",
+			Args:    []string{"arg"},
+		}},
+	}, {
+		name: "valid step - valid onError usage - set to stopAndFail - alpha API",
+		steps: []v1.Step{{
+			OnError: "stopAndFail",
+			Image:   "image",
+			Args:    []string{"arg"},
+		}},
+	}, {
+		name: "valid step - onError not set",
+		steps: []v1.Step{{
+			Image: "image",
+			Args:  []string{"arg"},
+		}},
+	}, {
+		name: "invalid step - onError set to invalid value - alpha API",
+		steps: []v1.Step{{
+			OnError: "onError",
+			Image:   "image",
+			Args:    []string{"arg"},
+		}},
+		expectedError: &apis.FieldError{
+			Message: fmt.Sprintf("invalid value: onError"),
+			Paths:   []string{"steps[0].onError"},
+			Details: "Task step onError must be either continue or stopAndFail",
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Steps: tt.steps,
+			}
+			ctx := context.Background()
+			ts.SetDefaults(ctx)
+			err := ts.Validate(ctx)
+			if tt.expectedError == nil && err != nil {
+				t.Errorf("TaskSpec.Validate() = %v", err)
+			} else if tt.expectedError != nil && err == nil {
+				t.Errorf("TaskSpec.Validate() = %v", err)
+			} else if tt.expectedError != nil {
+				if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+					t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+				}
+			}
+		})
+	}
+
+}
+
+func TestStepRetries(t *testing.T) {
+	tests := []struct {
+		name  string
+		steps []v1.Step
+	}{{
+		name: "no retries",
+		steps: []v1.Step{{
+			Image: "my-image",
+		}},
+	}, {
+		name: "retries without backoff",
+		steps: []v1.Step{{
+			Image:   "my-image",
+			Retries: 3,
+		}},
+	}, {
+		name: "retries with backoff",
+		steps: []v1.Step{{
+			Image:        "my-image",
+			Retries:      3,
+			RetryBackoff: &metav1.Duration{Duration: 10 * time.Second},
+		}},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Steps: tt.steps,
+			}
+			ctx := config.EnableAlphaAPIFields(context.Background())
+			if err := ts.Validate(ctx); err != nil {
+				t.Errorf("TaskSpec.Validate() = %v", err)
+			}
+		})
+	}
+}
+
+func TestStepRetriesErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		steps         []v1.Step
+		expectedError apis.FieldError
+	}{{
+		name: "negative retries",
+		steps: []v1.Step{{
+			Image:   "my-image",
+			Retries: -1,
+		}},
+		expectedError: apis.FieldError{
+			Message: "invalid value: -1",
+			Paths:   []string{"steps[0].retries"},
+			Details: "retries must not be negative",
+		},
+	}, {
+		name: "zero backoff",
+		steps: []v1.Step{{
+			Image:        "my-image",
+			Retries:      1,
+			RetryBackoff: &metav1.Duration{},
+		}},
+		expectedError: apis.FieldError{
+			Message: "invalid value: 0s",
+			Paths:   []string{"steps[0].retryBackoff"},
+			Details: "retry backoff must be positive",
+		},
+	}, {
+		name: "negative backoff",
+		steps: []v1.Step{{
+			Image:        "my-image",
+			Retries:      1,
+			RetryBackoff: &metav1.Duration{Duration: -1 * time.Second},
+		}},
+		expectedError: apis.FieldError{
+			Message: "invalid value: -1s",
+			Paths:   []string{"steps[0].retryBackoff"},
+			Details: "retry backoff must be positive",
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Steps: tt.steps,
+			}
+			ctx := config.EnableAlphaAPIFields(context.Background())
+			err := ts.Validate(ctx)
+			if err == nil {
+				t.Fatalf("Expected an error, got nothing for %v", ts)
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestStepWhen(t *testing.T) {
+	ts := &v1.TaskSpec{
+		Params: []v1.ParamSpec{{Name: "mode", Type: v1.ParamTypeString}},
+		Steps: []v1.Step{{
+			Image: "my-image",
+			When: v1.WhenExpressions{{
+				Input:    "$(params.mode)",
+				Operator: selection.In,
+				Values:   []string{"debug", "trace"},
+			}},
+		}},
+	}
+	ctx := config.EnableAlphaAPIFields(context.Background())
+	if err := ts.Validate(ctx); err != nil {
+		t.Errorf("TaskSpec.Validate() = %v", err)
+	}
+}
+
+func TestStepWhenErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		when          v1.WhenExpressions
+		expectedError apis.FieldError
+	}{{
+		name: "undeclared param in input",
+		when: v1.WhenExpressions{{
+			Input:    "$(params.missing)",
+			Operator: selection.In,
+			Values:   []string{"debug"},
+		}},
+		expectedError: apis.FieldError{
+			Message: `non-existent variable in "$(params.missing)"`,
+			Paths:   []string{"steps[0].when[0].input"},
+		},
+	}, {
+		name: "undeclared param in values",
+		when: v1.WhenExpressions{{
+			Input:    "debug",
+			Operator: selection.NotIn,
+			Values:   []string{"$(params.missing)"},
+		}},
+		expectedError: apis.FieldError{
+			Message: `non-existent variable in "$(params.missing)"`,
+			Paths:   []string{"steps[0].when[0].values"},
+		},
+	}, {
+		name: "invalid operator",
+		when: v1.WhenExpressions{{
+			Input:    "$(params.mode)",
+			Operator: selection.Exists,
+			Values:   []string{"debug"},
+		}},
+		expectedError: apis.FieldError{
+			Message: `invalid value: operator "exists" is not recognized. valid operators: in,notin`,
+			Paths:   []string{"steps[0].when[0]"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Params: []v1.ParamSpec{{Name: "mode", Type: v1.ParamTypeString}},
 				Steps: []v1.Step{{
-					Image:   "myimage",
-					Command: []string{"command"},
-					VolumeMounts: []corev1.VolumeMount{{
-						Name:      "my-mount",
-						MountPath: "/workspace/some-workspace/",
-					}},
-				}},
-				Workspaces: []v1.WorkspaceDeclaration{{
-					Name: "some-workspace",
+					Image: "my-image",
+					When:  tt.when,
 				}},
-			},
-			expectedError: apis.FieldError{
-				Message: "workspace mount path \"/workspace/some-workspace\" must be unique",
-				Paths:   []string{"workspaces[0].mountpath"},
-			},
+			}
+			ctx := config.EnableAlphaAPIFields(context.Background())
+			err := ts.Validate(ctx)
+			if err == nil {
+				t.Fatalf("Expected an error, got nothing for %v", ts)
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestStepNativeSidecars(t *testing.T) {
+	always := corev1.RestartPolicyAlways
+	ts := &v1.TaskSpec{
+		Steps: []v1.Step{{
+			Name:          "proxy",
+			Image:         "envoy",
+			RestartPolicy: &always,
 		}, {
-			name: "workspace mount path already in stepTemplate",
-			fields: fields{
-				StepTemplate: &v1.StepTemplate{
-					VolumeMounts: []corev1.VolumeMount{{
-						Name:      "my-mount",
-						MountPath: "/foo",
-					}},
-				},
-				Steps: validSteps,
-				Workspaces: []v1.WorkspaceDeclaration{{
-					Name:      "some-workspace",
-					MountPath: "/foo",
-				}},
-			},
-			expectedError: apis.FieldError{
-				Message: "workspace mount path \"/foo\" must be unique",
-				Paths:   []string{"workspaces[0].mountpath"},
-			},
+			Name:          "registry",
+			Image:         "registry",
+			RestartPolicy: &always,
 		}, {
-			name: "workspace default mount path already in stepTemplate",
-			fields: fields{
-				StepTemplate: &v1.StepTemplate{
-					VolumeMounts: []corev1.VolumeMount{{
-						Name:      "my-mount",
-						MountPath: "/workspace/some-workspace",
-					}},
-				},
-				Steps: validSteps,
-				Workspaces: []v1.WorkspaceDeclaration{{
-					Name: "some-workspace",
-				}},
-			},
-			expectedError: apis.FieldError{
-				Message: "workspace mount path \"/workspace/some-workspace\" must be unique",
-				Paths:   []string{"workspaces[0].mountpath"},
-			},
+			Name:  "build",
+			Image: "my-image",
+		}},
+	}
+	ctx := config.EnableAlphaAPIFields(context.Background())
+	if err := ts.Validate(ctx); err != nil {
+		t.Errorf("TaskSpec.Validate() = %v", err)
+	}
+}
+
+func TestStepNativeSidecarsErrors(t *testing.T) {
+	always, onFailure := corev1.RestartPolicyAlways, corev1.RestartPolicyOnFailure
+	tests := []struct {
+		name          string
+		steps         []v1.Step
+		expectedError apis.FieldError
+	}{{
+		name: "native sidecar after a regular step",
+		steps: []v1.Step{{
+			Name:  "build",
+			Image: "my-image",
 		}, {
-			name: "result name not validate",
-			fields: fields{
-				Steps: validSteps,
-				Results: []v1.TaskResult{{
-					Name:        "MY^RESULT",
-					Description: "my great result",
-				}},
-			},
-			expectedError: apis.FieldError{
-				Message: `invalid key name "MY^RESULT"`,
-				Paths:   []string{"results[0].name"},
-				Details: "Name must consist of alphanumeric characters, '-', '_', and must start and end with an alphanumeric character (e.g. 'MyName',  or 'my-name',  or 'my_name', regex used for validation is '^([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]$')",
-			},
+			Name:          "proxy",
+			Image:         "envoy",
+			RestartPolicy: &always,
+		}},
+		expectedError: apis.FieldError{
+			Message: `native sidecar step "proxy" must come before the other steps`,
+			Paths:   []string{"steps[1].restartPolicy"},
+		},
+	}, {
+		name: "unsupported restart policy",
+		steps: []v1.Step{{
+			Name:          "proxy",
+			Image:         "envoy",
+			RestartPolicy: &onFailure,
 		}, {
-			name: "result type not validate",
-			fields: fields{
-				Steps: validSteps,
-				Results: []v1.TaskResult{{
-					Name:        "MY-RESULT",
-					Type:        "wrong",
-					Description: "my great result",
-				}},
-			},
-			expectedError: apis.FieldError{
-				Message: `invalid value: wrong`,
-				Paths:   []string{"results[0].type"},
-				Details: "type must be string",
-			},
+			Name:  "build",
+			Image: "my-image",
+		}},
+		expectedError: apis.FieldError{
+			Message: `invalid value: OnFailure`,
+			Paths:   []string{"steps[0].restartPolicy"},
+			Details: `restartPolicy can only be "Always"`,
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{Steps: tt.steps}
+			ctx := config.EnableAlphaAPIFields(context.Background())
+			err := ts.Validate(ctx)
+			if err == nil {
+				t.Fatalf("Expected an error, got nothing for %v", ts)
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskSpec_ValidateStepTimeouts(t *testing.T) {
+	ts := &v1.TaskSpec{
+		Steps: []v1.Step{{
+			Name: "no-timeout", Image: "my-image",
 		}, {
-			name: "context not validate",
-			fields: fields{
-				Steps: []v1.Step{{
-					Image: "my-image",
-					Args:  []string{"arg"},
-					Script: `
-				#!/usr/bin/env  bash
-				hello "$(context.task.missing)"`,
-				}},
-			},
-			expectedError: apis.FieldError{
-				Message: `non-existent variable in "\n\t\t\t\t#!/usr/bin/env  bash\n\t\t\t\thello \"$(context.task.missing)\""`,
-				Paths:   []string{"steps[0].script"},
-			},
+			Name: "short", Image: "my-image", Timeout: &metav1.Duration{Duration: time.Minute},
 		}, {
-			name: "negative timeout string",
-			fields: fields{
-				Steps: []v1.Step{{
-					Timeout: &metav1.Duration{Duration: -10 * time.Second},
-				}},
-			},
-			expectedError: apis.FieldError{
-				Message: "invalid value: -10s",
-				Paths:   []string{"steps[0].negative timeout"},
-			},
-		}}
+			Name: "long", Image: "my-image", Timeout: &metav1.Duration{Duration: time.Hour},
+		}},
+	}
+	tests := []struct {
+		name        string
+		taskTimeout *metav1.Duration
+		wantErr     string
+	}{{
+		name:        "step timeouts within the task timeout",
+		taskTimeout: &metav1.Duration{Duration: 2 * time.Hour},
+	}, {
+		name:        "step timeout equal to the task timeout",
+		taskTimeout: &metav1.Duration{Duration: time.Hour},
+	}, {
+		name:        "step timeout exceeds the task timeout",
+		taskTimeout: &metav1.Duration{Duration: 30 * time.Minute},
+		wantErr:     "invalid value: 1h0m0s: steps[2].timeout\nstep timeout exceeds the task timeout of 30m0s",
+	}, {
+		name: "nil task timeout",
+	}, {
+		name:        "zero task timeout",
+		taskTimeout: &metav1.Duration{},
+	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ts := &v1.TaskSpec{
-				Params:       tt.fields.Params,
-				Steps:        tt.fields.Steps,
-				Volumes:      tt.fields.Volumes,
-				StepTemplate: tt.fields.StepTemplate,
-				Workspaces:   tt.fields.Workspaces,
-				Results:      tt.fields.Results,
+			err := ts.ValidateStepTimeouts(tt.taskTimeout)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("TaskSpec.ValidateStepTimeouts() = %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("TaskSpec.ValidateStepTimeouts() did not return error for a step timeout exceeding the task timeout")
+			}
+			if d := cmp.Diff(tt.wantErr, err.Error()); d != "" {
+				t.Errorf("TaskSpec.ValidateStepTimeouts() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskSpec_ValidateParamUsage(t *testing.T) {
+	steps := []v1.Step{{
+		Name:   "echo",
+		Image:  "ubuntu",
+		Script: "echo $(params.message)",
+		Args:   []string{"$(params.flags[*])", "$(params.target)"},
+	}}
+	tests := []struct {
+		name       string
+		params     []v1.ParamSpec
+		propagated []string
+		alpha      bool
+		wantErr    string
+	}{{
+		name: "all referenced params declared",
+		params: []v1.ParamSpec{{
+			Name: "message", Type: v1.ParamTypeString,
+		}, {
+			Name: "flags", Type: v1.ParamTypeArray,
+		}, {
+			Name: "target", Type: v1.ParamTypeString,
+		}},
+	}, {
+		name: "undeclared params referenced",
+		params: []v1.ParamSpec{{
+			Name: "flags", Type: v1.ParamTypeArray,
+		}},
+		wantErr: "param \"message\" is referenced but not declared: params\nparam \"target\" is referenced but not declared: params",
+	}, {
+		name: "propagated params are declared in alpha",
+		params: []v1.ParamSpec{{
+			Name: "flags", Type: v1.ParamTypeArray,
+		}},
+		propagated: []string{"message", "target"},
+		alpha:      true,
+	}, {
+		name: "params neither declared nor propagated in alpha",
+		params: []v1.ParamSpec{{
+			Name: "flags", Type: v1.ParamTypeArray,
+		}},
+		propagated: []string{"message"},
+		alpha:      true,
+		wantErr:    "param \"target\" is referenced but not declared: params",
+	}, {
+		name: "propagated params ignored without alpha",
+		params: []v1.ParamSpec{{
+			Name: "flags", Type: v1.ParamTypeArray,
+		}},
+		propagated: []string{"message", "target"},
+		wantErr:    "param \"message\" is referenced but not declared: params\nparam \"target\" is referenced but not declared: params",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.alpha {
+				ctx = config.EnableAlphaAPIFields(ctx)
+			}
+			ts := &v1.TaskSpec{Params: tt.params, Steps: steps}
+			err := ts.ValidateParamUsage(ctx, tt.propagated)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("TaskSpec.ValidateParamUsage() = %v", err)
+				}
+				return
 			}
-			ctx := config.EnableAlphaAPIFields(context.Background())
-			ts.SetDefaults(ctx)
-			err := ts.Validate(ctx)
 			if err == nil {
-				t.Fatalf("Expected an error, got nothing for %v", ts)
+				t.Fatal("TaskSpec.ValidateParamUsage() did not return error for undeclared params")
 			}
-			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
-				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			if d := cmp.Diff(tt.wantErr, err.Error()); d != "" {
+				t.Errorf("TaskSpec.ValidateParamUsage() %s", diff.PrintWantGot(d))
 			}
 		})
 	}
 }
 
-func TestStepAndSidecarWorkspaces(t *testing.T) {
-	type fields struct {
-		Steps      []v1.Step
-		Sidecars   []v1.Sidecar
-		Workspaces []v1.WorkspaceDeclaration
-	}
+func TestStepEnvValueFrom(t *testing.T) {
 	tests := []struct {
-		name   string
-		fields fields
+		name string
+		env  []corev1.EnvVar
 	}{{
-		name: "valid step workspace usage",
-		fields: fields{
-			Steps: []v1.Step{{
-				Image: "my-image",
-				Args:  []string{"arg"},
-				Workspaces: []v1.WorkspaceUsage{{
-					Name:      "foo-workspace",
-					MountPath: "/a/custom/mountpath",
-				}},
-			}},
-			Workspaces: []v1.WorkspaceDeclaration{{
-				Name:        "foo-workspace",
-				Description: "my great workspace",
-				MountPath:   "some/path",
+		name: "pod name",
+		env: []corev1.EnvVar{{
+			Name:      "POD_NAME",
+			ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"}},
+		}},
+	}, {
+		name: "node name",
+		env: []corev1.EnvVar{{
+			Name:      "NODE_NAME",
+			ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "spec.nodeName"}},
+		}},
+	}, {
+		name: "pod label",
+		env: []corev1.EnvVar{{
+			Name:      "APP",
+			ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.labels['app']"}},
+		}},
+	}, {
+		name: "field paths added in later Kubernetes versions",
+		env: []corev1.EnvVar{{
+			Name:      "HOST_IPS",
+			ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "status.hostIPs"}},
+		}, {
+			Name:      "POD_IPS",
+			ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "status.podIPs"}},
+		}},
+	}, {
+		name: "cpu and huge pages limits",
+		env: []corev1.EnvVar{{
+			Name:      "CPU_LIMIT",
+			ValueFrom: &corev1.EnvVarSource{ResourceFieldRef: &corev1.ResourceFieldSelector{Resource: "limits.cpu"}},
+		}, {
+			Name:      "HUGEPAGES_LIMIT",
+			ValueFrom: &corev1.EnvVarSource{ResourceFieldRef: &corev1.ResourceFieldSelector{Resource: "limits.hugepages-2Mi"}},
+		}},
+	}, {
+		name: "config map key",
+		env: []corev1.EnvVar{{
+			Name: "CONFIG",
+			ValueFrom: &corev1.EnvVarSource{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "config"},
+				Key:                  "key",
 			}},
-		},
+		}},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ts := &v1.TaskSpec{
-				Steps:      tt.fields.Steps,
-				Sidecars:   tt.fields.Sidecars,
-				Workspaces: tt.fields.Workspaces,
+				Steps: []v1.Step{{
+					Image: "my-image",
+					Env:   tt.env,
+				}},
 			}
-			ctx := config.EnableAlphaAPIFields(context.Background())
-			ts.SetDefaults(ctx)
-			if err := ts.Validate(ctx); err != nil {
+			if err := ts.Validate(context.Background()); err != nil {
 				t.Errorf("TaskSpec.Validate() = %v", err)
 			}
 		})
 	}
 }
 
-func TestStepAndSidecarWorkspacesErrors(t *testing.T) {
-	type fields struct {
-		Steps    []v1.Step
-		Sidecars []v1.Sidecar
-	}
+func TestStepEnvValueFromErrors(t *testing.T) {
 	tests := []struct {
 		name          string
-		fields        fields
+		env           []corev1.EnvVar
 		expectedError apis.FieldError
 	}{{
-		name: "step workspace that refers to non-existent workspace declaration fails",
-		fields: fields{
-			Steps: []v1.Step{{
-				Image: "foo",
-				Workspaces: []v1.WorkspaceUsage{{
-					Name: "foo",
-				}},
-			}},
-		},
+		name: "malformed field path",
+		env: []corev1.EnvVar{{
+			Name:  "FOO",
+			Value: "bar",
+		}, {
+			Name:      "APP",
+			ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.labels[app]"}},
+		}},
 		expectedError: apis.FieldError{
-			Message: `undefined workspace "foo"`,
-			Paths:   []string{"steps[0].workspaces[0].name"},
+			Message: "invalid value: metadata.labels[app]",
+			Paths:   []string{"steps[0].env[1].valueFrom.fieldRef.fieldPath"},
+			Details: "must be a dot-separated field path, optionally ending in a ['<key>'] subscript",
 		},
 	}, {
-		name: "sidecar workspace that refers to non-existent workspace declaration fails",
-		fields: fields{
-			Steps: []v1.Step{{
-				Image: "foo",
-			}},
-			Sidecars: []v1.Sidecar{{
-				Image: "foo",
-				Workspaces: []v1.WorkspaceUsage{{
-					Name: "foo",
-				}},
-			}},
+		name: "empty field path",
+		env: []corev1.EnvVar{{
+			Name:      "EMPTY",
+			ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{}},
+		}},
+		expectedError: apis.FieldError{
+			Message: "invalid value: ",
+			Paths:   []string{"steps[0].env[0].valueFrom.fieldRef.fieldPath"},
+			Details: "must be a dot-separated field path, optionally ending in a ['<key>'] subscript",
 		},
+	}, {
+		name: "unsupported resource",
+		env: []corev1.EnvVar{{
+			Name:      "GPU_LIMIT",
+			ValueFrom: &corev1.EnvVarSource{ResourceFieldRef: &corev1.ResourceFieldSelector{Resource: "limits.nvidia.com/gpu"}},
+		}},
 		expectedError: apis.FieldError{
-			Message: `undefined workspace "foo"`,
-			Paths:   []string{"sidecars[0].workspaces[0].name"},
+			Message: "invalid value: limits.nvidia.com/gpu",
+			Paths:   []string{"steps[0].env[0].valueFrom.resourceFieldRef.resource"},
+			Details: "supported values: limits.cpu, limits.ephemeral-storage, limits.memory, requests.cpu, requests.ephemeral-storage, requests.memory, limits.hugepages-<size>, requests.hugepages-<size>",
 		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ts := &v1.TaskSpec{
-				Steps:    tt.fields.Steps,
-				Sidecars: tt.fields.Sidecars,
+				Steps: []v1.Step{{
+					Image: "my-image",
+					Env:   tt.env,
+				}},
 			}
-
-			ctx := config.EnableAlphaAPIFields(context.Background())
-			ts.SetDefaults(ctx)
-			err := ts.Validate(ctx)
+			err := ts.Validate(context.Background())
 			if err == nil {
 				t.Fatalf("Expected an error, got nothing for %v", ts)
 			}
-
 			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
 				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
 			}
@@ -1309,59 +2191,10 @@ func TestStepAndSidecarWorkspacesErrors(t *testing.T) {
 	}
 }
 
-func TestStepOnError(t *testing.T) {
-	tests := []struct {
-		name          string
-		steps         []v1.Step
-		expectedError *apis.FieldError
-	}{{
-		name: "valid step - valid onError usage - set to continue - alpha API",
-		steps: []v1.Step{{
-			OnError: "continue",
-			Image:   "image",
-			Args:    []string{"arg"},
-		}},
-	}, {
-		name: "valid step - valid onError usage - set to stopAndFail - alpha API",
-		steps: []v1.Step{{
-			OnError: "stopAndFail",
-			Image:   "image",
-			Args:    []string{"arg"},
-		}},
-	}, {
-		name: "invalid step - onError set to invalid value - alpha API",
-		steps: []v1.Step{{
-			OnError: "onError",
-			Image:   "image",
-			Args:    []string{"arg"},
-		}},
-		expectedError: &apis.FieldError{
-			Message: fmt.Sprintf("invalid value: onError"),
-			Paths:   []string{"onError"},
-			Details: "Task step onError must be either continue or stopAndFail",
-		},
-	}}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			ts := &v1.TaskSpec{
-				Steps: tt.steps,
-			}
-			ctx := context.Background()
-			ts.SetDefaults(ctx)
-			err := ts.Validate(ctx)
-			if tt.expectedError == nil && err != nil {
-				t.Errorf("TaskSpec.Validate() = %v", err)
-			} else if tt.expectedError != nil && err == nil {
-				t.Errorf("TaskSpec.Validate() = %v", err)
-			}
-		})
-	}
-
-}
-
 // TestIncompatibleAPIVersions exercises validation of fields that
 // require a specific feature gate version in order to work.
 func TestIncompatibleAPIVersions(t *testing.T) {
+	alwaysRestart := corev1.RestartPolicyAlways
 	tests := []struct {
 		name            string
 		requiredVersion string
@@ -1397,6 +2230,17 @@ func TestIncompatibleAPIVersions(t *testing.T) {
 				}},
 			}},
 		},
+	}, {
+		name:            "param enum requires alpha",
+		requiredVersion: "alpha",
+		spec: v1.TaskSpec{
+			Params: []v1.ParamSpec{{
+				Name: "mode",
+				Type: v1.ParamTypeString,
+				Enum: []string{"fast", "slow"},
+			}},
+			Steps: validSteps,
+		},
 	}, {
 		name:            "windows script support requires alpha",
 		requiredVersion: "alpha",
@@ -1408,6 +2252,64 @@ func TestIncompatibleAPIVersions(t *testing.T) {
 				script-1`,
 			}},
 		},
+	}, {
+		name:            "stdout stream support requires alpha",
+		requiredVersion: "alpha",
+		spec: v1.TaskSpec{
+			Steps: []v1.Step{{
+				Image: "foo",
+				StdoutConfig: &v1.StepOutputConfig{
+					Path: "/tmp/stdout.txt",
+				},
+			}},
+		},
+	}, {
+		name:            "native sidecar steps requires alpha",
+		requiredVersion: "alpha",
+		spec: v1.TaskSpec{
+			Steps: []v1.Step{{
+				Image:         "foo",
+				RestartPolicy: &alwaysRestart,
+			}},
+		},
+	}, {
+		name:            "step retries requires alpha",
+		requiredVersion: "alpha",
+		spec: v1.TaskSpec{
+			Steps: []v1.Step{{
+				Image:   "foo",
+				Retries: 1,
+			}},
+		},
+	}, {
+		name:            "step retry backoff requires alpha",
+		requiredVersion: "alpha",
+		spec: v1.TaskSpec{
+			Steps: []v1.Step{{
+				Image:        "foo",
+				RetryBackoff: &metav1.Duration{Duration: time.Second},
+			}},
+		},
+	}, {
+		name:            "step when expressions requires alpha",
+		requiredVersion: "alpha",
+		spec: v1.TaskSpec{
+			Steps: []v1.Step{{
+				Image: "foo",
+				When:  v1.WhenExpressions{{Input: "foo", Operator: selection.In, Values: []string{"foo"}}},
+			}},
+		},
+	}, {
+		name:            "stderr stream support requires alpha",
+		requiredVersion: "alpha",
+		spec: v1.TaskSpec{
+			Steps: []v1.Step{{
+				Image: "foo",
+				StderrConfig: &v1.StepOutputConfig{
+					Path: "/tmp/stderr.txt",
+				},
+			}},
+		},
 	}}
 	versions := []string{"alpha", "stable"}
 	for _, tt := range tests {
@@ -1435,6 +2337,123 @@ func TestIncompatibleAPIVersions(t *testing.T) {
 	}
 }
 
+func TestParamSpec_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    v1.ParamSpec
+		wantErr string
+	}{{
+		name: "matching array default",
+		spec: v1.ParamSpec{Name: "flags", Type: v1.ParamTypeArray, Default: v1.NewArrayOrString("-v", "-x")},
+	}, {
+		name: "matching object default",
+		spec: v1.ParamSpec{
+			Name:       "gitrepo",
+			Type:       v1.ParamTypeObject,
+			Properties: map[string]v1.PropertySpec{"url": {Type: v1.ParamTypeString}, "commit": {Type: v1.ParamTypeString}},
+			Default:    v1.NewObject(map[string]string{"url": "https://example.com", "commit": "main"}),
+		},
+	}, {
+		name:    "string default for array param",
+		spec:    v1.ParamSpec{Name: "flags", Type: v1.ParamTypeArray, Default: v1.NewArrayOrString("-v")},
+		wantErr: `"array" type does not match default value's type: "string": flags.default.type, flags.type`,
+	}, {
+		name:    "array default for string param",
+		spec:    v1.ParamSpec{Name: "url", Type: v1.ParamTypeString, Default: v1.NewArrayOrString("a", "b")},
+		wantErr: `"string" type does not match default value's type: "array": url.default.type, url.type`,
+	}, {
+		name: "object default missing declared properties",
+		spec: v1.ParamSpec{
+			Name:       "gitrepo",
+			Type:       v1.ParamTypeObject,
+			Properties: map[string]v1.PropertySpec{"url": {Type: v1.ParamTypeString}, "commit": {Type: v1.ParamTypeString}},
+			Default:    v1.NewObject(map[string]string{"url": "https://example.com"}),
+		},
+		wantErr: "Required key(s) [commit] are missing in the value provider.: gitrepo.default, gitrepo.properties",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := config.EnableAlphaAPIFields(context.Background())
+			err := tt.spec.Validate(ctx)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ParamSpec.Validate() = %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Expected an error, got nothing for %v", tt.spec)
+			}
+			if d := cmp.Diff(tt.wantErr, err.Error()); d != "" {
+				t.Errorf("ParamSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestParamSpecEnum(t *testing.T) {
+	tests := []struct {
+		name          string
+		params        []v1.ParamSpec
+		expectedError *apis.FieldError
+	}{{
+		name: "valid enum and default",
+		params: []v1.ParamSpec{{
+			Name:    "mode",
+			Type:    v1.ParamTypeString,
+			Enum:    []string{"fast", "slow"},
+			Default: v1.NewArrayOrString("slow"),
+		}},
+	}, {
+		name: "default not in enum",
+		params: []v1.ParamSpec{{
+			Name:    "mode",
+			Type:    v1.ParamTypeString,
+			Enum:    []string{"fast", "slow"},
+			Default: v1.NewArrayOrString("medium"),
+		}},
+		expectedError: &apis.FieldError{
+			Message: "invalid value: medium",
+			Paths:   []string{"params.mode.default"},
+			Details: "allowed values are fast, slow",
+		},
+	}, {
+		name: "enum on an array param",
+		params: []v1.ParamSpec{{
+			Name: "modes",
+			Type: v1.ParamTypeArray,
+			Enum: []string{"fast", "slow"},
+		}},
+		expectedError: &apis.FieldError{
+			Message: `enum is only allowed for parameters of type string but "modes" is of type "array"`,
+			Paths:   []string{"params.modes.enum"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Params: tt.params,
+				Steps:  validSteps,
+			}
+			ctx := config.EnableAlphaAPIFields(context.Background())
+			ts.SetDefaults(ctx)
+			err := ts.Validate(ctx)
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Errorf("TaskSpec.Validate() = %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Expected an error, got nothing for %v", ts)
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestSubstitutedContext(t *testing.T) {
 	type fields struct {
 		Params              []v1.ParamSpec
@@ -0,0 +1,29 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// TaskRunStepSpec is used to override the values of a Step in the corresponding Task.
+type TaskRunStepSpec struct {
+	// The name of the Step to override.
+	Name string `json:"name"`
+	// The resource requirements to apply to the Step.
+	ComputeResources corev1.ResourceRequirements `json:"computeResources"`
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/pkg/apis"
+)
+
+var validWhenOperators = []string{
+	string(selection.In),
+	string(selection.NotIn),
+}
+
+func (wes WhenExpressions) validate() (errs *apis.FieldError) {
+	for idx, we := range wes {
+		errs = errs.Also(we.validateWhenExpressionFields().ViaIndex(idx))
+	}
+	return errs.ViaField("when")
+}
+
+func (we *WhenExpression) validateWhenExpressionFields() *apis.FieldError {
+	if equality.Semantic.DeepEqual(we, &WhenExpression{}) || we == nil {
+		return apis.ErrMissingField(apis.CurrentField)
+	}
+	if !sets.NewString(validWhenOperators...).Has(string(we.Operator)) {
+		message := fmt.Sprintf("operator %q is not recognized. valid operators: %s", we.Operator, strings.Join(validWhenOperators, ","))
+		return apis.ErrInvalidValue(message, apis.CurrentField)
+	}
+	if len(we.Values) == 0 {
+		return apis.ErrInvalidValue("expecting non-empty values field", apis.CurrentField)
+	}
+	return nil
+}
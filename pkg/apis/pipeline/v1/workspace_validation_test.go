@@ -20,16 +20,21 @@ import (
 	"context"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
 )
 
 func TestWorkspaceBindingValidateValid(t *testing.T) {
 	for _, tc := range []struct {
 		name    string
 		binding *v1.WorkspaceBinding
+		wc      func(context.Context) context.Context
 	}{{
 		name: "Valid PVC",
 		binding: &v1.WorkspaceBinding{
@@ -80,9 +85,43 @@ func TestWorkspaceBindingValidateValid(t *testing.T) {
 				SecretName: "my-secret",
 			},
 		},
+	}, {
+		name: "Valid projected",
+		binding: &v1.WorkspaceBinding{
+			Name: "beth",
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{{
+					ConfigMap: &corev1.ConfigMapProjection{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: "a-configmap-name",
+						},
+					},
+				}, {
+					Secret: &corev1.SecretProjection{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: "my-secret",
+						},
+					},
+				}},
+			},
+		},
+		wc: config.EnableAlphaAPIFields,
+	}, {
+		name: "Valid csi",
+		binding: &v1.WorkspaceBinding{
+			Name: "beth",
+			CSI: &corev1.CSIVolumeSource{
+				Driver: "my-csi",
+			},
+		},
+		wc: config.EnableAlphaAPIFields,
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
-			if err := tc.binding.Validate(context.Background()); err != nil {
+			ctx := context.Background()
+			if tc.wc != nil {
+				ctx = tc.wc(ctx)
+			}
+			if err := tc.binding.Validate(ctx); err != nil {
 				t.Errorf("didnt expect error for valid binding but got: %v", err)
 			}
 		})
@@ -94,6 +133,7 @@ func TestWorkspaceBindingValidateInvalid(t *testing.T) {
 	for _, tc := range []struct {
 		name    string
 		binding *v1.WorkspaceBinding
+		wc      func(context.Context) context.Context
 	}{{
 		name:    "no binding provided",
 		binding: nil,
@@ -129,11 +169,94 @@ func TestWorkspaceBindingValidateInvalid(t *testing.T) {
 			Name:   "beth",
 			Secret: &corev1.SecretVolumeSource{},
 		},
+	}, {
+		name: "projected workspace should be disallowed without alpha feature gate",
+		binding: &v1.WorkspaceBinding{
+			Name:      "beth",
+			Projected: &corev1.ProjectedVolumeSource{},
+		},
+	}, {
+		name: "Provide projected without sources",
+		binding: &v1.WorkspaceBinding{
+			Name:      "beth",
+			Projected: &corev1.ProjectedVolumeSource{},
+		},
+		wc: config.EnableAlphaAPIFields,
+	}, {
+		name: "csi workspace should be disallowed without alpha feature gate",
+		binding: &v1.WorkspaceBinding{
+			Name: "beth",
+			CSI: &corev1.CSIVolumeSource{
+				Driver: "csi-driver",
+			},
+		},
+	}, {
+		name: "Provide csi without a driver",
+		binding: &v1.WorkspaceBinding{
+			Name: "beth",
+			CSI: &corev1.CSIVolumeSource{
+				Driver: "",
+			},
+		},
+		wc: config.EnableAlphaAPIFields,
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
-			if err := tc.binding.Validate(context.Background()); err == nil {
+			ctx := context.Background()
+			if tc.wc != nil {
+				ctx = tc.wc(ctx)
+			}
+			if err := tc.binding.Validate(ctx); err == nil {
 				t.Errorf("expected error for invalid binding but didn't get any!")
 			}
 		})
 	}
 }
+
+func TestWorkspaceBindingValidateMultipleSources(t *testing.T) {
+	sources := map[string]func(*v1.WorkspaceBinding){
+		"persistentvolumeclaim": func(b *v1.WorkspaceBinding) {
+			b.PersistentVolumeClaim = &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "pool-party"}
+		},
+		"volumeclaimtemplate": func(b *v1.WorkspaceBinding) {
+			b.VolumeClaimTemplate = &corev1.PersistentVolumeClaim{}
+		},
+		"emptydir": func(b *v1.WorkspaceBinding) {
+			b.EmptyDir = &corev1.EmptyDirVolumeSource{}
+		},
+		"configmap": func(b *v1.WorkspaceBinding) {
+			b.ConfigMap = &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: "a-configmap-name"}}
+		},
+		"secret": func(b *v1.WorkspaceBinding) {
+			b.Secret = &corev1.SecretVolumeSource{SecretName: "my-secret"}
+		},
+		"projected": func(b *v1.WorkspaceBinding) {
+			b.Projected = &corev1.ProjectedVolumeSource{Sources: []corev1.VolumeProjection{{
+				Secret: &corev1.SecretProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "my-secret"}},
+			}}}
+		},
+		"csi": func(b *v1.WorkspaceBinding) {
+			b.CSI = &corev1.CSIVolumeSource{Driver: "my-csi"}
+		},
+	}
+	want := apis.ErrMultipleOneOf("persistentvolumeclaim", "volumeclaimtemplate", "emptydir", "configmap", "secret", "projected", "csi")
+	ctx := config.EnableAlphaAPIFields(context.Background())
+	for first, setFirst := range sources {
+		for second, setSecond := range sources {
+			if first >= second {
+				continue
+			}
+			t.Run(first+" and "+second, func(t *testing.T) {
+				b := &v1.WorkspaceBinding{Name: "beth"}
+				setFirst(b)
+				setSecond(b)
+				err := b.Validate(ctx)
+				if err == nil {
+					t.Fatalf("expected error for binding with multiple sources but didn't get any!")
+				}
+				if d := cmp.Diff(want.Error(), err.Error()); d != "" {
+					t.Errorf("WorkspaceBinding.Validate() errors diff %s", diff.PrintWantGot(d))
+				}
+			})
+		}
+	}
+}
@@ -87,6 +87,11 @@ func (in *ParamSpec) DeepCopyInto(out *ParamSpec) {
 		*out = new(ArrayOrString)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Enum != nil {
+		in, out := &in.Enum, &out.Enum
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -307,11 +312,38 @@ func (in *Step) DeepCopyInto(out *Step) {
 		*out = new(metav1.Duration)
 		**out = **in
 	}
+	if in.RetryBackoff != nil {
+		in, out := &in.RetryBackoff, &out.RetryBackoff
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	if in.Workspaces != nil {
 		in, out := &in.Workspaces, &out.Workspaces
 		*out = make([]WorkspaceUsage, len(*in))
 		copy(*out, *in)
 	}
+	if in.When != nil {
+		in, out := &in.When, &out.When
+		*out = make(WhenExpressions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RestartPolicy != nil {
+		in, out := &in.RestartPolicy, &out.RestartPolicy
+		*out = new(corev1.RestartPolicy)
+		**out = **in
+	}
+	if in.StdoutConfig != nil {
+		in, out := &in.StdoutConfig, &out.StdoutConfig
+		*out = new(StepOutputConfig)
+		**out = **in
+	}
+	if in.StderrConfig != nil {
+		in, out := &in.StderrConfig, &out.StderrConfig
+		*out = new(StepOutputConfig)
+		**out = **in
+	}
 	return
 }
 
@@ -325,6 +357,22 @@ func (in *Step) DeepCopy() *Step {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StepOutputConfig) DeepCopyInto(out *StepOutputConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StepOutputConfig.
+func (in *StepOutputConfig) DeepCopy() *StepOutputConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(StepOutputConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StepTemplate) DeepCopyInto(out *StepTemplate) {
 	*out = *in
@@ -483,6 +531,23 @@ func (in *TaskRunResult) DeepCopy() *TaskRunResult {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskRunStepSpec) DeepCopyInto(out *TaskRunStepSpec) {
+	*out = *in
+	in.ComputeResources.DeepCopyInto(&out.ComputeResources)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskRunStepSpec.
+func (in *TaskRunStepSpec) DeepCopy() *TaskRunStepSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskRunStepSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TaskSpec) DeepCopyInto(out *TaskSpec) {
 	*out = *in
@@ -544,6 +609,49 @@ func (in *TaskSpec) DeepCopy() *TaskSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WhenExpression) DeepCopyInto(out *WhenExpression) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WhenExpression.
+func (in *WhenExpression) DeepCopy() *WhenExpression {
+	if in == nil {
+		return nil
+	}
+	out := new(WhenExpression)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in WhenExpressions) DeepCopyInto(out *WhenExpressions) {
+	{
+		in := &in
+		*out = make(WhenExpressions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+		return
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WhenExpressions.
+func (in WhenExpressions) DeepCopy() WhenExpressions {
+	if in == nil {
+		return nil
+	}
+	out := new(WhenExpressions)
+	in.DeepCopyInto(out)
+	return *out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkspaceBinding) DeepCopyInto(out *WorkspaceBinding) {
 	*out = *in
@@ -572,6 +680,16 @@ func (in *WorkspaceBinding) DeepCopyInto(out *WorkspaceBinding) {
 		*out = new(corev1.SecretVolumeSource)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Projected != nil {
+		in, out := &in.Projected, &out.Projected
+		*out = new(corev1.ProjectedVolumeSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CSI != nil {
+		in, out := &in.CSI, &out.CSI
+		*out = new(corev1.CSIVolumeSource)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -0,0 +1,145 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+)
+
+// ConvertTo converts the Step to a v1 Step. The deprecated container fields of the Step were
+// removed in v1, so an error is returned if any of them is set.
+func (s *Step) ConvertTo(ctx context.Context, sink *v1.Step) error {
+	var unsupported []string
+	if len(s.DeprecatedPorts) != 0 {
+		unsupported = append(unsupported, "ports")
+	}
+	if s.DeprecatedLivenessProbe != nil {
+		unsupported = append(unsupported, "livenessProbe")
+	}
+	if s.DeprecatedReadinessProbe != nil {
+		unsupported = append(unsupported, "readinessProbe")
+	}
+	if s.DeprecatedStartupProbe != nil {
+		unsupported = append(unsupported, "startupProbe")
+	}
+	if s.DeprecatedLifecycle != nil {
+		unsupported = append(unsupported, "lifecycle")
+	}
+	if s.DeprecatedTerminationMessagePath != "" {
+		unsupported = append(unsupported, "terminationMessagePath")
+	}
+	if s.DeprecatedTerminationMessagePolicy != "" {
+		unsupported = append(unsupported, "terminationMessagePolicy")
+	}
+	if s.DeprecatedStdin {
+		unsupported = append(unsupported, "stdin")
+	}
+	if s.DeprecatedStdinOnce {
+		unsupported = append(unsupported, "stdinOnce")
+	}
+	if s.DeprecatedTTY {
+		unsupported = append(unsupported, "tty")
+	}
+	if len(unsupported) != 0 {
+		return fmt.Errorf("step %q sets %s, which have no v1 equivalent", s.Name, strings.Join(unsupported, ", "))
+	}
+
+	// Deep copy the Step so that the v1 Step doesn't share any memory with it.
+	c := s.DeepCopy()
+	*sink = v1.Step{
+		Name:            c.Name,
+		Image:           c.Image,
+		Command:         c.Command,
+		Args:            c.Args,
+		WorkingDir:      c.WorkingDir,
+		EnvFrom:         c.EnvFrom,
+		Env:             c.Env,
+		Resources:       c.Resources,
+		VolumeMounts:    c.VolumeMounts,
+		VolumeDevices:   c.VolumeDevices,
+		ImagePullPolicy: c.ImagePullPolicy,
+		SecurityContext: c.SecurityContext,
+		Script:          c.Script,
+		Timeout:         c.Timeout,
+		OnError:         c.OnError,
+	}
+	for _, w := range c.Workspaces {
+		sink.Workspaces = append(sink.Workspaces, v1.WorkspaceUsage{Name: w.Name, MountPath: w.MountPath})
+	}
+	if c.StdoutConfig != nil {
+		sink.StdoutConfig = &v1.StepOutputConfig{Path: c.StdoutConfig.Path}
+	}
+	if c.StderrConfig != nil {
+		sink.StderrConfig = &v1.StepOutputConfig{Path: c.StderrConfig.Path}
+	}
+	return nil
+}
+
+// ConvertFrom sets the Step to the given v1 Step. Retries, RetryBackoff, When and RestartPolicy
+// have no v1beta1 equivalent, so an error is returned if any of them is set.
+func (s *Step) ConvertFrom(ctx context.Context, source v1.Step) error {
+	var unsupported []string
+	if source.Retries != 0 {
+		unsupported = append(unsupported, "retries")
+	}
+	if source.RetryBackoff != nil {
+		unsupported = append(unsupported, "retryBackoff")
+	}
+	if len(source.When) != 0 {
+		unsupported = append(unsupported, "when")
+	}
+	if source.RestartPolicy != nil {
+		unsupported = append(unsupported, "restartPolicy")
+	}
+	if len(unsupported) != 0 {
+		return fmt.Errorf("step %q sets %s, which have no v1beta1 equivalent", source.Name, strings.Join(unsupported, ", "))
+	}
+
+	// Deep copy the source so that the Step doesn't share any memory with it.
+	source = *source.DeepCopy()
+	*s = Step{
+		Name:            source.Name,
+		Image:           source.Image,
+		Command:         source.Command,
+		Args:            source.Args,
+		WorkingDir:      source.WorkingDir,
+		EnvFrom:         source.EnvFrom,
+		Env:             source.Env,
+		Resources:       source.Resources,
+		VolumeMounts:    source.VolumeMounts,
+		VolumeDevices:   source.VolumeDevices,
+		ImagePullPolicy: source.ImagePullPolicy,
+		SecurityContext: source.SecurityContext,
+		Script:          source.Script,
+		Timeout:         source.Timeout,
+		OnError:         source.OnError,
+	}
+	for _, w := range source.Workspaces {
+		s.Workspaces = append(s.Workspaces, WorkspaceUsage{Name: w.Name, MountPath: w.MountPath})
+	}
+	if source.StdoutConfig != nil {
+		s.StdoutConfig = &StepOutputConfig{Path: source.StdoutConfig.Path}
+	}
+	if source.StderrConfig != nil {
+		s.StderrConfig = &StepOutputConfig{Path: source.StderrConfig.Path}
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/selection"
+)
+
+func TestStepConversion(t *testing.T) {
+	runAsNonRoot := true
+	step := Step{
+		Name:       "build",
+		Image:      "golang",
+		Command:    []string{"go"},
+		Args:       []string{"build", "./..."},
+		WorkingDir: "/workspace/source",
+		EnvFrom: []corev1.EnvFromSource{{
+			ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "env"}},
+		}},
+		Env: []corev1.EnvVar{{Name: "GOFLAGS", Value: "-mod=vendor"}},
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+		},
+		VolumeMounts:    []corev1.VolumeMount{{Name: "cache", MountPath: "/cache"}},
+		VolumeDevices:   []corev1.VolumeDevice{{Name: "disk", DevicePath: "/dev/disk"}},
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		SecurityContext: &corev1.SecurityContext{RunAsNonRoot: &runAsNonRoot},
+		Script:          "#!/bin/sh\ngo build ./...",
+		Timeout:         &metav1.Duration{Duration: time.Minute},
+		Workspaces:      []WorkspaceUsage{{Name: "source", MountPath: "/source"}},
+		OnError:         "continue",
+		StdoutConfig:    &StepOutputConfig{Path: "/tekton/stdout"},
+		StderrConfig:    &StepOutputConfig{Path: "/tekton/stderr"},
+	}
+
+	v1Step := v1.Step{}
+	if err := step.ConvertTo(context.Background(), &v1Step); err != nil {
+		t.Fatalf("ConvertTo() = %v", err)
+	}
+	got := Step{}
+	if err := got.ConvertFrom(context.Background(), v1Step); err != nil {
+		t.Fatalf("ConvertFrom() = %v", err)
+	}
+	if d := cmp.Diff(step, got); d != "" {
+		t.Errorf("Step wasn't the same after a round trip through v1 %s", diff.PrintWantGot(d))
+	}
+
+	roundTripped := v1.Step{}
+	if err := got.ConvertTo(context.Background(), &roundTripped); err != nil {
+		t.Fatalf("ConvertTo() = %v", err)
+	}
+	if d := cmp.Diff(v1Step, roundTripped); d != "" {
+		t.Errorf("v1 Step wasn't the same after a round trip through v1beta1 %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestStepConvertToReusedSink(t *testing.T) {
+	step := Step{
+		Name:    "build",
+		Image:   "golang",
+		Args:    []string{"build"},
+		Env:     []corev1.EnvVar{{Name: "GOFLAGS", Value: "-mod=vendor"}},
+		Timeout: &metav1.Duration{Duration: time.Minute},
+	}
+	always := corev1.RestartPolicyAlways
+	sink := v1.Step{
+		Name:          "stale",
+		Retries:       3,
+		RetryBackoff:  &metav1.Duration{Duration: time.Second},
+		When:          v1.WhenExpressions{{Input: "foo", Operator: selection.In, Values: []string{"foo"}}},
+		RestartPolicy: &always,
+		StdoutConfig:  &v1.StepOutputConfig{Path: "/tekton/stdout"},
+		StderrConfig:  &v1.StepOutputConfig{Path: "/tekton/stderr"},
+	}
+	if err := step.ConvertTo(context.Background(), &sink); err != nil {
+		t.Fatalf("ConvertTo() = %v", err)
+	}
+	want := v1.Step{
+		Name:    "build",
+		Image:   "golang",
+		Args:    []string{"build"},
+		Env:     []corev1.EnvVar{{Name: "GOFLAGS", Value: "-mod=vendor"}},
+		Timeout: &metav1.Duration{Duration: time.Minute},
+	}
+	if d := cmp.Diff(want, sink); d != "" {
+		t.Errorf("ConvertTo() kept fields of the reused sink %s", diff.PrintWantGot(d))
+	}
+
+	sink.Args[0] = "test"
+	sink.Env[0].Value = "-mod=mod"
+	sink.Timeout.Duration = time.Hour
+	if step.Args[0] != "build" || step.Env[0].Value != "-mod=vendor" || step.Timeout.Duration != time.Minute {
+		t.Errorf("ConvertTo() returned a v1 Step sharing memory with the Step: %+v", step)
+	}
+}
+
+func TestStepConvertToError(t *testing.T) {
+	step := Step{
+		Name:                    "build",
+		Image:                   "golang",
+		DeprecatedLivenessProbe: &corev1.Probe{},
+		DeprecatedTTY:           true,
+	}
+	want := `step "build" sets livenessProbe, tty, which have no v1 equivalent`
+	err := step.ConvertTo(context.Background(), &v1.Step{})
+	if err == nil {
+		t.Fatalf("ConvertTo() = nil, wanted error")
+	}
+	if d := cmp.Diff(want, err.Error()); d != "" {
+		t.Errorf("ConvertTo() %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestStepConvertFromError(t *testing.T) {
+	always := corev1.RestartPolicyAlways
+	source := v1.Step{
+		Name:    "build",
+		Image:   "golang",
+		Retries: 2,
+		When: v1.WhenExpressions{{
+			Input:    "foo",
+			Operator: selection.In,
+			Values:   []string{"foo"},
+		}},
+		RestartPolicy: &always,
+	}
+	want := `step "build" sets retries, when, restartPolicy, which have no v1beta1 equivalent`
+	step := Step{}
+	err := step.ConvertFrom(context.Background(), source)
+	if err == nil {
+		t.Fatalf("ConvertFrom() = nil, wanted error")
+	}
+	if d := cmp.Diff(want, err.Error()); d != "" {
+		t.Errorf("ConvertFrom() %s", diff.PrintWantGot(d))
+	}
+}
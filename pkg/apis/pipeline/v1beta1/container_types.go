@@ -1,6 +1,7 @@
 package v1beta1
 
 import (
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -277,6 +278,13 @@ func (s *Step) SetContainerFields(c corev1.Container) {
 	s.DeprecatedTTY = c.TTY
 }
 
+// ContainerName returns the name of the container that runs the Step at the given index of its Task:
+// "step-" followed by the Step name, or "step-unnamed-<index>" if the Step has no name, shortened
+// to the maximum valid container name length if needed.
+func (s *Step) ContainerName(index int) string {
+	return (&v1.Step{Name: s.Name}).ContainerName(index)
+}
+
 // StepTemplate is a template for a Step
 type StepTemplate struct {
 
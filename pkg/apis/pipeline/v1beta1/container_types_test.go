@@ -0,0 +1,54 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+)
+
+func TestStepContainerName(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		step  v1beta1.Step
+		index int
+		want  string
+	}{{
+		name:  "named step",
+		step:  v1beta1.Step{Name: "build"},
+		index: 2,
+		want:  "step-build",
+	}, {
+		name:  "unnamed step",
+		step:  v1beta1.Step{Image: "busybox"},
+		index: 2,
+		want:  "step-unnamed-2",
+	}, {
+		name:  "long step name is shortened",
+		step:  v1beta1.Step{Name: strings.Repeat("a", 70)},
+		index: 0,
+		want:  "step-" + strings.Repeat("a", 58),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.step.ContainerName(tc.index); got != tc.want {
+				t.Errorf("ContainerName(%d) = %q, want %q", tc.index, got, tc.want)
+			}
+		})
+	}
+}
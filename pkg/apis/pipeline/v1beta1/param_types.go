@@ -53,6 +53,24 @@ type ParamSpec struct {
 	// parameter.
 	// +optional
 	Default *ArrayOrString `json:"default,omitempty"`
+	// Enum declares the values that a string parameter is allowed to take. If set, both
+	// the value supplied for the parameter and its Default must be one of them.
+	// +optional
+	// +listType=atomic
+	Enum []string `json:"enum,omitempty"`
+}
+
+// AllowsValue returns true if the ParamSpec declares no Enum or if value is one of its values.
+func (pp *ParamSpec) AllowsValue(value string) bool {
+	if len(pp.Enum) == 0 {
+		return true
+	}
+	for _, e := range pp.Enum {
+		if e == value {
+			return true
+		}
+	}
+	return false
 }
 
 // PropertySpec defines the struct for object keys
@@ -110,6 +128,22 @@ type Param struct {
 	Value ArrayOrString `json:"value"`
 }
 
+// InferredType returns the type of the Param's value: its Type if it's set, and otherwise array or object if
+// it holds array or object values, or string. It's how a Param that isn't declared by a ParamSpec, e.g. a
+// propagated one, is typed.
+func (p Param) InferredType() ParamType {
+	switch {
+	case p.Value.Type != "":
+		return p.Value.Type
+	case p.Value.ArrayVal != nil:
+		return ParamTypeArray
+	case p.Value.ObjectVal != nil:
+		return ParamTypeObject
+	default:
+		return ParamTypeString
+	}
+}
+
 // ParamType indicates the type of an input parameter;
 // Used to distinguish between a single string and an array of strings.
 type ParamType string
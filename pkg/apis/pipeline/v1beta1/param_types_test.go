@@ -290,6 +290,34 @@ func TestArrayOrString_ApplyReplacements(t *testing.T) {
 			"key1": "foo",
 			"key2": "bar",
 		}),
+	}, {
+		name: "param replacement on object property",
+		args: args{
+			input: v1beta1.NewObject(map[string]string{
+				"url":    "https://$(params.sub).example.com",
+				"commit": "$(params.sub)",
+			}),
+			stringReplacements: map[string]string{"params.sub": "abc"},
+		},
+		expectedOutput: v1beta1.NewObject(map[string]string{
+			"url":    "https://abc.example.com",
+			"commit": "abc",
+		}),
+	}, {
+		name: "array param references on object properties",
+		args: args{
+			input: v1beta1.NewObject(map[string]string{
+				"first": "$(params.myarray[0])",
+				"all":   "$(params.myarray[*])",
+			}),
+			stringReplacements: map[string]string{"params.myarray[0]": "a", "params.myarray[1]": "b"},
+			arrayReplacements:  map[string][]string{"params.myarray": {"a", "b"}},
+		},
+		// object property values are strings, so only array elements can be substituted into them
+		expectedOutput: v1beta1.NewObject(map[string]string{
+			"first": "a",
+			"all":   "$(params.myarray[*])",
+		}),
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -453,3 +481,37 @@ func TestArrayReference(t *testing.T) {
 		}
 	}
 }
+
+func TestParam_InferredType(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		param v1beta1.Param
+		want  v1beta1.ParamType
+	}{{
+		name:  "string value",
+		param: v1beta1.Param{Name: "revision", Value: *v1beta1.NewArrayOrString("main")},
+		want:  v1beta1.ParamTypeString,
+	}, {
+		name:  "array value",
+		param: v1beta1.Param{Name: "flags", Value: *v1beta1.NewArrayOrString("-v", "-x")},
+		want:  v1beta1.ParamTypeArray,
+	}, {
+		name:  "untyped array value",
+		param: v1beta1.Param{Name: "flags", Value: v1beta1.ArrayOrString{ArrayVal: []string{"-v"}}},
+		want:  v1beta1.ParamTypeArray,
+	}, {
+		name:  "untyped object value",
+		param: v1beta1.Param{Name: "repo", Value: v1beta1.ArrayOrString{ObjectVal: map[string]string{"url": "https://example.com"}}},
+		want:  v1beta1.ParamTypeObject,
+	}, {
+		name:  "empty value",
+		param: v1beta1.Param{Name: "revision"},
+		want:  v1beta1.ParamTypeString,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.param.InferredType(); got != tc.want {
+				t.Errorf("InferredType() = %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
@@ -18,6 +18,7 @@ package v1beta1
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -118,6 +119,247 @@ type PipelineSpec struct {
 	Finally []PipelineTask `json:"finally,omitempty"`
 }
 
+// NamedTaskRunSpec pairs a PipelineTask's name with the TaskRunSpec it would be run with.
+type NamedTaskRunSpec struct {
+	PipelineTaskName string      `json:"pipelineTaskName"`
+	TaskRunSpec      TaskRunSpec `json:"taskRunSpec"`
+}
+
+// ToTaskRunSpecs returns the TaskRunSpecs of the Tasks and Finally Tasks that a run of the PipelineSpec
+// with the given params would create, in declaration order. Pipeline params are substituted into each
+// PipelineTask's params; references to the results of other PipelineTasks are left as they are since
+// they're only known at run time. Matrices aren't fanned out: a matrixed PipelineTask produces a single
+// TaskRunSpec with only its non-matrix params. Custom Tasks aren't run as TaskRuns so they are an error.
+func (ps *PipelineSpec) ToTaskRunSpecs(params []Param) ([]NamedTaskRunSpec, error) {
+	if err := ps.ValidateParams(params); err != nil {
+		return nil, err
+	}
+	stringReplacements, arrayReplacements, objectReplacements := ps.paramReplacements(params)
+
+	var specs []NamedTaskRunSpec
+	for _, tasks := range [][]PipelineTask{ps.Tasks, ps.Finally} {
+		for i := range tasks {
+			pt := tasks[i].DeepCopy()
+			if pt.IsCustomTask() {
+				return nil, fmt.Errorf("pipeline task %q is a custom task which isn't run as a TaskRun", pt.Name)
+			}
+			trs := TaskRunSpec{
+				Timeout: pt.Timeout,
+			}
+			if spec, ref, isEmbedded := pt.TaskSpecOrRef(); isEmbedded {
+				trs.TaskSpec = &spec.TaskSpec
+			} else {
+				trs.TaskRef = ref
+			}
+			for _, p := range pt.Params {
+				p.Value.ApplyReplacements(stringReplacements, arrayReplacements, objectReplacements)
+				trs.Params = append(trs.Params, p)
+			}
+			specs = append(specs, NamedTaskRunSpec{PipelineTaskName: pt.Name, TaskRunSpec: trs})
+		}
+	}
+	return specs, nil
+}
+
+// ResolveParams returns a value for each of the PipelineSpec's params, in declaration order, taking it from
+// provided and falling back to the declared default. The provided params are checked as ValidateParams does,
+// so an error is returned if a param without a default isn't provided.
+func (ps *PipelineSpec) ResolveParams(provided []Param) ([]Param, error) {
+	if err := ps.ValidateParams(provided); err != nil {
+		return nil, err
+	}
+	values := map[string]ArrayOrString{}
+	for _, p := range provided {
+		values[p.Name] = p.Value
+	}
+
+	resolved := make([]Param, 0, len(ps.Params))
+	for _, spec := range ps.Params {
+		value, ok := values[spec.Name]
+		if !ok {
+			value = *spec.Default
+		}
+		resolved = append(resolved, Param{Name: spec.Name, Value: *value.DeepCopy()})
+	}
+	return resolved, nil
+}
+
+// paramReplacements returns the replacements for references to the PipelineSpec's params, taking the
+// values from params and falling back to the declared defaults.
+func (ps *PipelineSpec) paramReplacements(params []Param) (map[string]string, map[string][]string, map[string]map[string]string) {
+	stringReplacements := map[string]string{}
+	arrayReplacements := map[string][]string{}
+	objectReplacements := map[string]map[string]string{}
+	patterns := []string{
+		"params.%s",
+		"params[%q]",
+		"params['%s']",
+	}
+
+	values := map[string]ArrayOrString{}
+	for _, p := range ps.Params {
+		if p.Default != nil {
+			values[p.Name] = *p.Default
+		}
+	}
+	for _, p := range params {
+		values[p.Name] = p.Value
+	}
+
+	for name, value := range values {
+		for _, pattern := range patterns {
+			switch value.Type {
+			case ParamTypeArray:
+				arrayReplacements[fmt.Sprintf(pattern, name)] = value.ArrayVal
+			case ParamTypeObject:
+				objectReplacements[fmt.Sprintf(pattern, name)] = value.ObjectVal
+			default:
+				stringReplacements[fmt.Sprintf(pattern, name)] = value.StringVal
+			}
+		}
+		for k, v := range value.ObjectVal {
+			stringReplacements[fmt.Sprintf("params.%s.%s", name, k)] = v
+		}
+	}
+	return stringReplacements, arrayReplacements, objectReplacements
+}
+
+// TaskByName returns the Task or Finally Task of the PipelineSpec with the given name, or nil if there
+// is none. The returned PipelineTask points into the PipelineSpec.
+func (ps *PipelineSpec) TaskByName(name string) *PipelineTask {
+	for _, tasks := range [][]PipelineTask{ps.Tasks, ps.Finally} {
+		for i := range tasks {
+			if tasks[i].Name == name {
+				return &tasks[i]
+			}
+		}
+	}
+	return nil
+}
+
+// PropagatedParams returns the params of the PipelineSpec that are propagated to the embedded TaskSpec of
+// the Task with the given name, in declaration order. Pipeline params are available to an embedded TaskSpec
+// without being passed to it, unless the PipelineTask passes a param of the same name explicitly, in which
+// case that value takes precedence. Only Tasks are considered: params aren't propagated to Finally Tasks, nor
+// to Tasks that refer to a Task, so nil is returned for them and for names that don't match any Task.
+// Params are only propagated when the "enable-api-fields" feature gate is "alpha", nil is returned otherwise.
+func (ps *PipelineSpec) PropagatedParams(ctx context.Context, taskName string) []ParamSpec {
+	if config.FromContextOrDefaults(ctx).FeatureFlags.EnableAPIFields != config.AlphaAPIFields {
+		return nil
+	}
+	var pt *PipelineTask
+	for i := range ps.Tasks {
+		if ps.Tasks[i].Name == taskName {
+			pt = &ps.Tasks[i]
+			break
+		}
+	}
+	if pt == nil || pt.TaskSpec == nil {
+		return nil
+	}
+	passed := sets.NewString()
+	for _, p := range pt.Params {
+		passed.Insert(p.Name)
+	}
+	var propagated []ParamSpec
+	for _, p := range ps.Params {
+		if !passed.Has(p.Name) {
+			propagated = append(propagated, p)
+		}
+	}
+	return propagated
+}
+
+// SharedPVCWorkspaces returns the names of the PipelineSpec's workspaces that are bound to a
+// PersistentVolumeClaim, directly or through a VolumeClaimTemplate, by the given bindings and that are
+// used by more than one of its Tasks and Finally Tasks. The TaskRuns sharing such a workspace need to
+// be placed on the same node by an affinity assistant.
+func (ps *PipelineSpec) SharedPVCWorkspaces(bindings []WorkspaceBinding) sets.String {
+	pvcWorkspaces := sets.NewString()
+	for _, b := range bindings {
+		if b.PersistentVolumeClaim != nil || b.VolumeClaimTemplate != nil {
+			pvcWorkspaces.Insert(b.Name)
+		}
+	}
+	usages := map[string]int{}
+	for _, tasks := range [][]PipelineTask{ps.Tasks, ps.Finally} {
+		for i := range tasks {
+			for _, name := range tasks[i].WorkspaceNames().List() {
+				usages[name]++
+			}
+		}
+	}
+	shared := sets.NewString()
+	for name, count := range usages {
+		if count > 1 && pvcWorkspaces.Has(name) {
+			shared.Insert(name)
+		}
+	}
+	return shared
+}
+
+// SortedTasks returns the PipelineSpec's Tasks in dependency order, so that every Task comes after the
+// Tasks it depends on. Tasks whose dependencies are satisfied at the same point keep their declaration
+// order. An error is returned if the dependencies have a cycle or refer to a Task that doesn't exist.
+func (ps *PipelineSpec) SortedTasks() ([]PipelineTask, error) {
+	if err := ps.ValidateDAG(); err != nil {
+		return nil, err
+	}
+	deps := PipelineTaskList(ps.Tasks).Deps()
+	sorted := make([]PipelineTask, 0, len(ps.Tasks))
+	done := sets.NewString()
+	for len(sorted) < len(ps.Tasks) {
+		var ready []string
+		for _, pt := range ps.Tasks {
+			if !done.Has(pt.Name) && done.HasAll(deps[pt.Name]...) {
+				ready = append(ready, pt.Name)
+				sorted = append(sorted, pt)
+			}
+		}
+		// The graph was checked for cycles above, so some Task is always ready until all are sorted.
+		done.Insert(ready...)
+	}
+	return sorted, nil
+}
+
+// SkippedTasks returns the names of the Tasks and Finally Tasks of the PipelineSpec that would be skipped
+// given the params of the PipelineRun and the results of the Tasks that ran, keyed by Task name and then by
+// result name. The when expressions of each Task are evaluated with the results and the params substituted
+// into them, falling back to the defaults of the Pipeline's params that aren't in params. A Task is also skipped if any of the Tasks it depends on is skipped. An error is returned if the
+// Tasks have a dependency cycle, or if a when expression refers to a result that isn't in results.
+func (ps *PipelineSpec) SkippedTasks(params []Param, results map[string]map[string]string) (sets.String, error) {
+	sorted, err := ps.SortedTasks()
+	if err != nil {
+		return nil, err
+	}
+	replacements, _, _ := ps.paramReplacements(params)
+	for task, taskResults := range results {
+		for name, value := range taskResults {
+			replacements[fmt.Sprintf("%s.%s.%s.%s", ResultTaskPart, task, ResultResultPart, name)] = value
+		}
+	}
+
+	skipped := sets.NewString()
+	for _, pt := range append(sorted, ps.Finally...) {
+		if skipped.HasAny(pt.Deps()...) {
+			skipped.Insert(pt.Name)
+			continue
+		}
+		for _, we := range pt.WhenExpressions {
+			expressions, _ := we.GetVarSubstitutionExpressions()
+			for _, ref := range NewResultRefs(expressions) {
+				if _, ok := results[ref.PipelineTask][ref.Result]; !ok {
+					return nil, fmt.Errorf("pipeline task %q refers to result %q of pipeline task %q which isn't known", pt.Name, ref.Result, ref.PipelineTask)
+				}
+			}
+		}
+		if !pt.WhenExpressions.AllowsExecutionWith(replacements) {
+			skipped.Insert(pt.Name)
+		}
+	}
+	return skipped, nil
+}
+
 // PipelineResult used to describe the results of a pipeline
 type PipelineResult struct {
 	// Name the given name
@@ -136,6 +378,47 @@ type PipelineResult struct {
 	Value ArrayOrString `json:"value"`
 }
 
+// Resolve returns the value of the PipelineResult with its references to task results, which have the
+// form $(tasks.<taskName>.results.<resultName>), replaced by the values in taskResults, keyed by task name
+// and then result name, in the same way as ResolvePipelineResults does for a PipelineRun. The value of an
+// array or object PipelineResult is returned JSON encoded. An error is returned if a reference can't be
+// resolved, including references to elements of array or object results, since taskResults only holds
+// string values.
+func (pr PipelineResult) Resolve(taskResults map[string]map[string]string) (string, error) {
+	taskRunResults := make(map[string][]TaskRunResult, len(taskResults))
+	for taskName, results := range taskResults {
+		for name, value := range results {
+			taskRunResults[taskName] = append(taskRunResults[taskName], TaskRunResult{Name: name, Value: *NewArrayOrString(value)})
+		}
+	}
+	resolved, err := ResolvePipelineResults([]PipelineResult{pr}, taskRunResults, nil)
+	if err != nil {
+		return "", err
+	}
+	// a PipelineResult without references isn't resolved, its value is used as is
+	value := pr.Value
+	if len(resolved) != 0 {
+		value = resolved[0].Value
+	}
+	switch value.Type {
+	case ParamTypeArray:
+		b, err := json.Marshal(value.ArrayVal)
+		return string(b), err
+	case ParamTypeObject:
+		b, err := json.Marshal(value.ObjectVal)
+		return string(b), err
+	default:
+		return value.StringVal, nil
+	}
+}
+
+// AggregateResults resolves the PipelineSpec's Results from taskRunResults, the results of the child TaskRuns
+// keyed by pipeline task name, and returns them with their declared types, as they are reported in the
+// PipelineRun status: it's ResolvePipelineResults, which the PipelineRun reconciler uses too.
+func (ps *PipelineSpec) AggregateResults(taskRunResults map[string][]TaskRunResult) ([]PipelineRunResult, error) {
+	return ResolvePipelineResults(ps.Results, taskRunResults, nil)
+}
+
 // PipelineTaskMetadata contains the labels or annotations for an EmbeddedTask
 type PipelineTaskMetadata struct {
 	// +optional
@@ -162,6 +445,30 @@ type EmbeddedTask struct {
 	TaskSpec `json:",inline,omitempty"`
 }
 
+// MergeMetadata adds the labels and annotations of the EmbeddedTask's metadata to into. Keys that are
+// already set in into keep their values.
+func (et *EmbeddedTask) MergeMetadata(into *metav1.ObjectMeta) {
+	if et == nil || into == nil {
+		return
+	}
+	into.Labels = mergeMissingKeys(into.Labels, et.Metadata.Labels)
+	into.Annotations = mergeMissingKeys(into.Annotations, et.Metadata.Annotations)
+}
+
+// mergeMissingKeys adds the entries of added whose keys aren't in metadata to it, allocating metadata
+// if it's nil and there's something to add, and returns it.
+func mergeMissingKeys(metadata, added map[string]string) map[string]string {
+	for key, value := range added {
+		if metadata == nil {
+			metadata = make(map[string]string, len(added))
+		}
+		if _, ok := metadata[key]; !ok {
+			metadata[key] = value
+		}
+	}
+	return metadata
+}
+
 // PipelineTask defines a task in a Pipeline, passing inputs from both
 // Params and from the output of previous tasks.
 type PipelineTask struct {
@@ -444,6 +751,20 @@ func validateExecutionStatusVariablesExpressions(expressions []string, ptNames s
 	return errs
 }
 
+// WorkspaceNames returns the names of the pipeline workspaces that the PipelineTask binds to. A binding
+// that doesn't name a pipeline workspace binds the one with the same name as the task's workspace.
+func (pt *PipelineTask) WorkspaceNames() sets.String {
+	names := sets.NewString()
+	for _, ws := range pt.Workspaces {
+		if ws.Workspace == "" {
+			names.Insert(ws.Name)
+		} else {
+			names.Insert(ws.Workspace)
+		}
+	}
+	return names
+}
+
 func (pt *PipelineTask) validateWorkspaces(workspaceNames sets.String) (errs *apis.FieldError) {
 	for i, ws := range pt.Workspaces {
 		if ws.Workspace == "" {
@@ -468,6 +789,29 @@ func (pt *PipelineTask) TaskSpecMetadata() PipelineTaskMetadata {
 	return pt.TaskSpec.Metadata
 }
 
+// TaskSpecOrRef returns the PipelineTask's embedded spec or its reference, and whether the
+// Task is embedded. At most one of spec and ref is non-nil; both are nil if the PipelineTask
+// sets neither or, invalidly, both of them.
+func (pt *PipelineTask) TaskSpecOrRef() (spec *EmbeddedTask, ref *TaskRef, isEmbedded bool) {
+	switch {
+	case pt.TaskSpec != nil && pt.TaskRef == nil:
+		return pt.TaskSpec, nil, true
+	case pt.TaskRef != nil && pt.TaskSpec == nil:
+		return nil, pt.TaskRef, false
+	}
+	return nil, nil, false
+}
+
+// IsCustomTask returns true if the PipelineTask references or embeds a Custom Task, i.e. sets
+// both the apiVersion and the kind of its taskRef or taskSpec.
+func (pt *PipelineTask) IsCustomTask() bool {
+	spec, ref, isEmbedded := pt.TaskSpecOrRef()
+	if isEmbedded {
+		return spec.APIVersion != "" && spec.Kind != ""
+	}
+	return ref != nil && ref.APIVersion != "" && ref.Kind != ""
+}
+
 // HashKey is the name of the PipelineTask, and is used as the key for this PipelineTask in the DAG
 func (pt PipelineTask) HashKey() string {
 	return pt.Name
@@ -24,7 +24,10 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"knative.dev/pkg/apis"
 )
@@ -415,6 +418,198 @@ func TestPipelineTaskList_Names(t *testing.T) {
 	}
 }
 
+func TestPipelineTask_WorkspaceNames(t *testing.T) {
+	pt := PipelineTask{
+		Name: "build",
+		Workspaces: []WorkspacePipelineTaskBinding{{
+			Name: "source", Workspace: "shared",
+		}, {
+			Name: "cache",
+		}, {
+			Name: "output", Workspace: "shared",
+		}},
+	}
+	expectedNames := sets.NewString("shared", "cache")
+	if d := cmp.Diff(expectedNames, pt.WorkspaceNames()); d != "" {
+		t.Errorf("PipelineTask.WorkspaceNames() %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestEmbeddedTask_MergeMetadata(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata PipelineTaskMetadata
+		into     metav1.ObjectMeta
+		want     metav1.ObjectMeta
+	}{{
+		name: "no embedded metadata",
+		into: metav1.ObjectMeta{Labels: map[string]string{"app": "build"}},
+		want: metav1.ObjectMeta{Labels: map[string]string{"app": "build"}},
+	}, {
+		name: "into has no metadata",
+		metadata: PipelineTaskMetadata{
+			Labels:      map[string]string{"app": "build"},
+			Annotations: map[string]string{"owner": "ci"},
+		},
+		want: metav1.ObjectMeta{
+			Labels:      map[string]string{"app": "build"},
+			Annotations: map[string]string{"owner": "ci"},
+		},
+	}, {
+		name: "non-conflicting keys",
+		metadata: PipelineTaskMetadata{
+			Labels:      map[string]string{"tier": "backend"},
+			Annotations: map[string]string{"owner": "ci"},
+		},
+		into: metav1.ObjectMeta{
+			Labels:      map[string]string{"app": "build"},
+			Annotations: map[string]string{"note": "nightly"},
+		},
+		want: metav1.ObjectMeta{
+			Labels:      map[string]string{"app": "build", "tier": "backend"},
+			Annotations: map[string]string{"note": "nightly", "owner": "ci"},
+		},
+	}, {
+		name: "existing keys win",
+		metadata: PipelineTaskMetadata{
+			Labels:      map[string]string{"app": "embedded", "tier": "backend"},
+			Annotations: map[string]string{"owner": "embedded"},
+		},
+		into: metav1.ObjectMeta{
+			Labels:      map[string]string{"app": "build"},
+			Annotations: map[string]string{"owner": "ci"},
+		},
+		want: metav1.ObjectMeta{
+			Labels:      map[string]string{"app": "build", "tier": "backend"},
+			Annotations: map[string]string{"owner": "ci"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			et := &EmbeddedTask{Metadata: tt.metadata}
+			et.MergeMetadata(&tt.into)
+			if d := cmp.Diff(tt.want, tt.into); d != "" {
+				t.Errorf("EmbeddedTask.MergeMetadata() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPipelineTask_TaskSpecOrRef(t *testing.T) {
+	embedded := &EmbeddedTask{TaskSpec: getTaskSpec()}
+	ref := &TaskRef{Name: "task"}
+	customSpec := &EmbeddedTask{TypeMeta: runtime.TypeMeta{APIVersion: "example.dev/v0", Kind: "Example"}}
+	customRef := &TaskRef{APIVersion: "example.dev/v0", Kind: "Example"}
+	tests := []struct {
+		name           string
+		pt             PipelineTask
+		wantSpec       *EmbeddedTask
+		wantRef        *TaskRef
+		wantEmbedded   bool
+		wantCustomTask bool
+	}{{
+		name:         "embedded task",
+		pt:           PipelineTask{Name: "foo", TaskSpec: embedded},
+		wantSpec:     embedded,
+		wantEmbedded: true,
+	}, {
+		name:    "referenced task",
+		pt:      PipelineTask{Name: "foo", TaskRef: ref},
+		wantRef: ref,
+	}, {
+		name:           "embedded custom task",
+		pt:             PipelineTask{Name: "foo", TaskSpec: customSpec},
+		wantSpec:       customSpec,
+		wantEmbedded:   true,
+		wantCustomTask: true,
+	}, {
+		name:           "referenced custom task",
+		pt:             PipelineTask{Name: "foo", TaskRef: customRef},
+		wantRef:        customRef,
+		wantCustomTask: true,
+	}, {
+		name:    "apiVersion without kind is not a custom task",
+		pt:      PipelineTask{Name: "foo", TaskRef: &TaskRef{APIVersion: "example.dev/v0", Name: "task"}},
+		wantRef: &TaskRef{APIVersion: "example.dev/v0", Name: "task"},
+	}, {
+		name: "both embedded and referenced",
+		pt:   PipelineTask{Name: "foo", TaskSpec: customSpec, TaskRef: customRef},
+	}, {
+		name: "neither embedded nor referenced",
+		pt:   PipelineTask{Name: "foo"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec, ref, isEmbedded := tt.pt.TaskSpecOrRef()
+			if d := cmp.Diff(tt.wantSpec, spec); d != "" {
+				t.Errorf("PipelineTask.TaskSpecOrRef() spec %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(tt.wantRef, ref); d != "" {
+				t.Errorf("PipelineTask.TaskSpecOrRef() ref %s", diff.PrintWantGot(d))
+			}
+			if isEmbedded != tt.wantEmbedded {
+				t.Errorf("PipelineTask.TaskSpecOrRef() isEmbedded = %t, want %t", isEmbedded, tt.wantEmbedded)
+			}
+			if got := tt.pt.IsCustomTask(); got != tt.wantCustomTask {
+				t.Errorf("PipelineTask.IsCustomTask() = %t, want %t", got, tt.wantCustomTask)
+			}
+		})
+	}
+}
+
+func TestPipelineTask_Deps(t *testing.T) {
+	tests := []struct {
+		name         string
+		task         PipelineTask
+		expectedDeps []string
+	}{{
+		name:         "no deps",
+		task:         PipelineTask{Name: "task-1"},
+		expectedDeps: []string{},
+	}, {
+		name: "ordering deps only",
+		task: PipelineTask{
+			Name:     "task-3",
+			RunAfter: []string{"task-2", "task-1", "task-2"},
+		},
+		expectedDeps: []string{"task-1", "task-2"},
+	}, {
+		name: "result reference deps only",
+		task: PipelineTask{
+			Name: "task-4",
+			Params: []Param{{
+				Name: "param", Value: ArrayOrString{Type: ParamTypeString, StringVal: "$(tasks.task-1.results.result)"},
+			}},
+			Matrix: []Param{{
+				Name: "platform", Value: ArrayOrString{Type: ParamTypeArray, ArrayVal: []string{"$(tasks.task-2.results.platform)"}},
+			}},
+			WhenExpressions: WhenExpressions{{
+				Input:    "$(tasks.task-3.results.branch)",
+				Operator: selection.In,
+				Values:   []string{"main"},
+			}},
+		},
+		expectedDeps: []string{"task-1", "task-2", "task-3"},
+	}, {
+		name: "ordering and result reference deps",
+		task: PipelineTask{
+			Name:     "task-4",
+			RunAfter: []string{"task-1", "task-2"},
+			Params: []Param{{
+				Name: "param", Value: ArrayOrString{Type: ParamTypeString, StringVal: "$(tasks.task-2.results.result) $(tasks.task-3.results.result)"},
+			}},
+		},
+		expectedDeps: []string{"task-1", "task-2", "task-3"},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if d := cmp.Diff(tc.expectedDeps, tc.task.Deps()); d != "" {
+				t.Errorf("PipelineTask.Deps() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestPipelineTaskList_Deps(t *testing.T) {
 	pipelines := []struct {
 		name         string
@@ -789,6 +984,20 @@ func TestPipelineTask_validateMatrix(t *testing.T) {
 			Message: "invalid value: parameters of type array only are allowed in matrix",
 			Paths:   []string{"matrix[foo]", "matrix[bar]"},
 		},
+	}, {
+		name: "parameters in matrix are objects",
+		pt: &PipelineTask{
+			Name: "task",
+			Matrix: []Param{{
+				Name: "foo", Value: ArrayOrString{Type: ParamTypeObject, ObjectVal: map[string]string{"key": "foo"}},
+			}, {
+				Name: "bar", Value: ArrayOrString{Type: ParamTypeArray, ArrayVal: []string{"bar", "foo"}},
+			}},
+		},
+		wantErrs: &apis.FieldError{
+			Message: "invalid value: parameters of type array only are allowed in matrix",
+			Paths:   []string{"matrix[foo]"},
+		},
 	}, {
 		name: "parameters in matrix are arrays",
 		pt: &PipelineTask{
@@ -1046,3 +1255,641 @@ func TestPipelineTask_ValidateEmbeddedOrType(t *testing.T) {
 		})
 	}
 }
+
+func TestPipelineSpec_ToTaskRunSpecs(t *testing.T) {
+	ps := &PipelineSpec{
+		Params: []ParamSpec{{
+			Name: "repo", Type: ParamTypeString,
+		}, {
+			Name: "revision", Type: ParamTypeString, Default: NewArrayOrString("main"),
+		}, {
+			Name: "flags", Type: ParamTypeArray, Default: NewArrayOrString("-v"),
+		}},
+		Tasks: []PipelineTask{{
+			Name:    "clone",
+			TaskRef: &TaskRef{Name: "git-clone"},
+			Params: []Param{{
+				Name: "url", Value: *NewArrayOrString("$(params.repo)"),
+			}, {
+				Name: "revision", Value: *NewArrayOrString("$(params.revision)"),
+			}},
+		}, {
+			Name: "build",
+			TaskSpec: &EmbeddedTask{TaskSpec: TaskSpec{
+				Steps: []Step{{Name: "build", Image: "builder"}},
+			}},
+			Params: []Param{{
+				Name: "commit", Value: *NewArrayOrString("$(tasks.clone.results.commit)"),
+			}, {
+				Name: "args", Value: *NewArrayOrString("build", "$(params.flags[*])"),
+			}},
+		}},
+	}
+	want := []NamedTaskRunSpec{{
+		PipelineTaskName: "clone",
+		TaskRunSpec: TaskRunSpec{
+			TaskRef: &TaskRef{Name: "git-clone"},
+			Params: []Param{{
+				Name: "url", Value: *NewArrayOrString("https://github.com/tektoncd/pipeline"),
+			}, {
+				Name: "revision", Value: *NewArrayOrString("main"),
+			}},
+		},
+	}, {
+		PipelineTaskName: "build",
+		TaskRunSpec: TaskRunSpec{
+			TaskSpec: &TaskSpec{
+				Steps: []Step{{Name: "build", Image: "builder"}},
+			},
+			Params: []Param{{
+				Name: "commit", Value: *NewArrayOrString("$(tasks.clone.results.commit)"),
+			}, {
+				Name: "args", Value: *NewArrayOrString("build", "-v", "-x"),
+			}},
+		},
+	}}
+	original := ps.DeepCopy()
+
+	got, err := ps.ToTaskRunSpecs([]Param{{
+		Name: "repo", Value: *NewArrayOrString("https://github.com/tektoncd/pipeline"),
+	}, {
+		Name: "flags", Value: *NewArrayOrString("-v", "-x"),
+	}})
+	if err != nil {
+		t.Fatalf("PipelineSpec.ToTaskRunSpecs() returned unexpected error: %v", err)
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("PipelineSpec.ToTaskRunSpecs() %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff(original, ps); d != "" {
+		t.Errorf("PipelineSpec.ToTaskRunSpecs() modified the PipelineSpec %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestPipelineSpec_ToTaskRunSpecs_Error(t *testing.T) {
+	tests := []struct {
+		name    string
+		ps      *PipelineSpec
+		params  []Param
+		wantErr string
+	}{{
+		name: "missing required param",
+		ps: &PipelineSpec{
+			Params: []ParamSpec{{Name: "repo", Type: ParamTypeString}},
+			Tasks: []PipelineTask{{
+				Name: "clone", TaskRef: &TaskRef{Name: "git-clone"},
+			}},
+		},
+		wantErr: "missing required params: repo: params",
+	}, {
+		name: "custom task",
+		ps: &PipelineSpec{
+			Tasks: []PipelineTask{{
+				Name: "wait", TaskRef: &TaskRef{APIVersion: "example.dev/v0", Kind: "Wait"},
+			}},
+		},
+		wantErr: `pipeline task "wait" is a custom task which isn't run as a TaskRun`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.ps.ToTaskRunSpecs(tt.params)
+			if err == nil {
+				t.Fatal("PipelineSpec.ToTaskRunSpecs() did not return an error")
+			}
+			if d := cmp.Diff(tt.wantErr, err.Error()); d != "" {
+				t.Errorf("PipelineSpec.ToTaskRunSpecs() error %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPipelineSpec_ResolveParams(t *testing.T) {
+	ps := &PipelineSpec{
+		Params: []ParamSpec{{
+			Name: "repo", Type: ParamTypeString,
+		}, {
+			Name: "revision", Type: ParamTypeString, Default: NewArrayOrString("main"),
+		}, {
+			Name: "flags", Type: ParamTypeArray, Default: NewArrayOrString("-v", "-race"),
+		}},
+	}
+	tests := []struct {
+		name     string
+		ps       *PipelineSpec
+		provided []Param
+		want     []Param
+	}{{
+		name: "all defaulted",
+		ps: &PipelineSpec{
+			Params: ps.Params[1:],
+		},
+		want: []Param{{
+			Name: "revision", Value: *NewArrayOrString("main"),
+		}, {
+			Name: "flags", Value: *NewArrayOrString("-v", "-race"),
+		}},
+	}, {
+		name: "partially provided",
+		ps:   ps,
+		provided: []Param{{
+			Name: "revision", Value: *NewArrayOrString("v0.1.0"),
+		}, {
+			Name: "repo", Value: *NewArrayOrString("https://github.com/tektoncd/pipeline"),
+		}},
+		want: []Param{{
+			Name: "repo", Value: *NewArrayOrString("https://github.com/tektoncd/pipeline"),
+		}, {
+			Name: "revision", Value: *NewArrayOrString("v0.1.0"),
+		}, {
+			Name: "flags", Value: *NewArrayOrString("-v", "-race"),
+		}},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.ps.ResolveParams(tt.provided)
+			if err != nil {
+				t.Fatalf("PipelineSpec.ResolveParams() = %v", err)
+			}
+			if d := cmp.Diff(tt.want, got); d != "" {
+				t.Errorf("PipelineSpec.ResolveParams() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPipelineSpec_ResolveParams_MissingRequired(t *testing.T) {
+	ps := &PipelineSpec{
+		Params: []ParamSpec{{
+			Name: "repo", Type: ParamTypeString,
+		}, {
+			Name: "revision", Type: ParamTypeString, Default: NewArrayOrString("main"),
+		}},
+	}
+	_, err := ps.ResolveParams([]Param{{Name: "revision", Value: *NewArrayOrString("v0.1.0")}})
+	if err == nil {
+		t.Fatal("PipelineSpec.ResolveParams() did not return an error")
+	}
+	if d := cmp.Diff("missing required params: repo: params", err.Error()); d != "" {
+		t.Errorf("PipelineSpec.ResolveParams() error %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestPipelineSpec_TaskByName(t *testing.T) {
+	ps := &PipelineSpec{
+		Tasks:   []PipelineTask{{Name: "build"}, {Name: "test"}},
+		Finally: []PipelineTask{{Name: "report"}},
+	}
+	for _, name := range []string{"build", "test", "report"} {
+		pt := ps.TaskByName(name)
+		if pt == nil || pt.Name != name {
+			t.Errorf("PipelineSpec.TaskByName(%q) = %v, want the task named %q", name, pt, name)
+		}
+	}
+	if pt := ps.TaskByName("deploy"); pt != nil {
+		t.Errorf("PipelineSpec.TaskByName(%q) = %v, want nil", "deploy", pt)
+	}
+}
+
+func TestPipelineSpec_PropagatedParams(t *testing.T) {
+	ps := &PipelineSpec{
+		Params: []ParamSpec{{
+			Name: "foo", Type: ParamTypeString,
+		}, {
+			Name: "bar", Type: ParamTypeString,
+		}},
+		Tasks: []PipelineTask{{
+			Name: "echo",
+			TaskSpec: &EmbeddedTask{TaskSpec: TaskSpec{
+				Steps: []Step{{
+					Name:   "echo",
+					Image:  "ubuntu",
+					Script: "echo $(params.foo) $(params.bar)",
+				}},
+			}},
+			Params: []Param{{
+				Name: "bar", Value: *NewArrayOrString("override"),
+			}},
+		}, {
+			Name:    "referenced",
+			TaskRef: &TaskRef{Name: "task"},
+		}},
+		Finally: []PipelineTask{{
+			Name: "report",
+			TaskSpec: &EmbeddedTask{TaskSpec: TaskSpec{
+				Steps: []Step{{
+					Name:   "echo",
+					Image:  "ubuntu",
+					Script: "echo $(params.foo)",
+				}},
+			}},
+		}},
+	}
+	tests := []struct {
+		name     string
+		taskName string
+		stable   bool
+		want     []ParamSpec
+	}{{
+		name:     "embedded task uses undeclared param",
+		taskName: "echo",
+		want:     []ParamSpec{{Name: "foo", Type: ParamTypeString}},
+	}, {
+		name:     "params aren't propagated without the alpha feature gate",
+		taskName: "echo",
+		stable:   true,
+	}, {
+		name:     "task reference",
+		taskName: "referenced",
+	}, {
+		name:     "finally task",
+		taskName: "report",
+	}, {
+		name:     "unknown task",
+		taskName: "deploy",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := config.EnableAlphaAPIFields(context.Background())
+			if tt.stable {
+				ctx = context.Background()
+			}
+			got := ps.PropagatedParams(ctx, tt.taskName)
+			if d := cmp.Diff(tt.want, got); d != "" {
+				t.Errorf("PipelineSpec.PropagatedParams(%q) %s", tt.taskName, diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPipelineSpec_SharedPVCWorkspaces(t *testing.T) {
+	ps := &PipelineSpec{
+		Workspaces: []PipelineWorkspaceDeclaration{{Name: "source"}, {Name: "cache"}, {Name: "scratch"}},
+		Tasks: []PipelineTask{{
+			Name:    "build",
+			TaskRef: &TaskRef{Name: "build"},
+			Workspaces: []WorkspacePipelineTaskBinding{{
+				Name: "src", Workspace: "source",
+			}, {
+				Name: "cache",
+			}, {
+				Name: "scratch",
+			}},
+		}},
+		Finally: []PipelineTask{{
+			Name:    "report",
+			TaskRef: &TaskRef{Name: "report"},
+			Workspaces: []WorkspacePipelineTaskBinding{{
+				Name: "source",
+			}, {
+				Name: "scratch",
+			}},
+		}},
+	}
+	bindings := []WorkspaceBinding{{
+		Name:                  "source",
+		PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "pvc"},
+	}, {
+		Name:                "cache",
+		VolumeClaimTemplate: &corev1.PersistentVolumeClaim{},
+	}, {
+		Name:     "scratch",
+		EmptyDir: &corev1.EmptyDirVolumeSource{},
+	}}
+	want := sets.NewString("source")
+	if d := cmp.Diff(want, ps.SharedPVCWorkspaces(bindings)); d != "" {
+		t.Errorf("PipelineSpec.SharedPVCWorkspaces() %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestPipelineSpec_SortedTasks(t *testing.T) {
+	tests := []struct {
+		name      string
+		tasks     []PipelineTask
+		wantNames []string
+	}{{
+		name: "linear chain",
+		tasks: []PipelineTask{{
+			Name: "deploy", TaskRef: &TaskRef{Name: "task"}, RunAfter: []string{"test"},
+		}, {
+			Name: "test", TaskRef: &TaskRef{Name: "task"},
+			Params: []Param{{
+				Name: "image", Value: *NewArrayOrString("$(tasks.build.results.image)"),
+			}},
+		}, {
+			Name: "build", TaskRef: &TaskRef{Name: "task"},
+		}},
+		wantNames: []string{"build", "test", "deploy"},
+	}, {
+		name: "diamond",
+		tasks: []PipelineTask{{
+			Name: "d", TaskRef: &TaskRef{Name: "task"}, RunAfter: []string{"b", "c"},
+		}, {
+			Name: "c", TaskRef: &TaskRef{Name: "task"}, RunAfter: []string{"a"},
+		}, {
+			Name: "b", TaskRef: &TaskRef{Name: "task"}, RunAfter: []string{"a"},
+		}, {
+			Name: "a", TaskRef: &TaskRef{Name: "task"},
+		}},
+		wantNames: []string{"a", "c", "b", "d"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ps := &PipelineSpec{Tasks: tt.tasks}
+			sorted, err := ps.SortedTasks()
+			if err != nil {
+				t.Fatalf("PipelineSpec.SortedTasks() returned unexpected error: %v", err)
+			}
+			var gotNames []string
+			for _, pt := range sorted {
+				gotNames = append(gotNames, pt.Name)
+			}
+			if d := cmp.Diff(tt.wantNames, gotNames); d != "" {
+				t.Errorf("PipelineSpec.SortedTasks() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPipelineSpec_SortedTasks_Cycle(t *testing.T) {
+	ps := &PipelineSpec{Tasks: []PipelineTask{{
+		Name: "a", TaskRef: &TaskRef{Name: "task"}, RunAfter: []string{"b"},
+	}, {
+		Name: "b", TaskRef: &TaskRef{Name: "task"}, RunAfter: []string{"a"},
+	}}}
+	if _, err := ps.SortedTasks(); err == nil {
+		t.Error("PipelineSpec.SortedTasks() did not return error for a dependency cycle")
+	}
+}
+
+func TestPipelineSpec_SkippedTasks(t *testing.T) {
+	ps := &PipelineSpec{
+		Params: []ParamSpec{{Name: "env", Type: ParamTypeString, Default: NewArrayOrString("staging")}},
+		Tasks: []PipelineTask{{
+			Name: "check", TaskRef: &TaskRef{Name: "check"},
+		}, {
+			Name: "build", TaskRef: &TaskRef{Name: "build"},
+			WhenExpressions: WhenExpressions{{
+				Input: "$(tasks.check.results.changed)", Operator: selection.In, Values: []string{"true"},
+			}},
+		}, {
+			Name: "test", TaskRef: &TaskRef{Name: "test"}, RunAfter: []string{"build"},
+		}, {
+			Name: "deploy", TaskRef: &TaskRef{Name: "deploy"},
+			Params: []Param{{Name: "image", Value: *NewArrayOrString("$(tasks.build.results.image)")}},
+		}, {
+			Name: "lint", TaskRef: &TaskRef{Name: "lint"},
+			WhenExpressions: WhenExpressions{{
+				Input: "$(params.env)", Operator: selection.NotIn, Values: []string{"production"},
+			}},
+		}},
+		Finally: []PipelineTask{{
+			Name: "notify", TaskRef: &TaskRef{Name: "notify"},
+		}},
+	}
+	tests := []struct {
+		name    string
+		params  []Param
+		results map[string]map[string]string
+		want    sets.String
+	}{{
+		name:    "when skips a task and its dependents",
+		results: map[string]map[string]string{"check": {"changed": "false"}},
+		want:    sets.NewString("build", "test", "deploy"),
+	}, {
+		name:    "nothing skipped",
+		results: map[string]map[string]string{"check": {"changed": "true"}},
+		want:    sets.NewString(),
+	}, {
+		name:    "pipelinerun param overrides the default",
+		params:  []Param{{Name: "env", Value: *NewArrayOrString("production")}},
+		results: map[string]map[string]string{"check": {"changed": "true"}},
+		want:    sets.NewString("lint"),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ps.SkippedTasks(tt.params, tt.results)
+			if err != nil {
+				t.Fatalf("PipelineSpec.SkippedTasks() returned unexpected error: %v", err)
+			}
+			if d := cmp.Diff(tt.want, got); d != "" {
+				t.Errorf("PipelineSpec.SkippedTasks() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPipelineSpec_SkippedTasks_Error(t *testing.T) {
+	ps := &PipelineSpec{Tasks: []PipelineTask{{
+		Name: "check", TaskRef: &TaskRef{Name: "check"},
+	}, {
+		Name: "build", TaskRef: &TaskRef{Name: "build"},
+		WhenExpressions: WhenExpressions{{
+			Input: "$(tasks.check.results.changed)", Operator: selection.In, Values: []string{"true"},
+		}},
+	}}}
+	_, err := ps.SkippedTasks(nil, map[string]map[string]string{})
+	if err == nil {
+		t.Fatal("PipelineSpec.SkippedTasks() did not return error for an unknown result")
+	}
+	want := `pipeline task "build" refers to result "changed" of pipeline task "check" which isn't known`
+	if d := cmp.Diff(want, err.Error()); d != "" {
+		t.Errorf("PipelineSpec.SkippedTasks() %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestPipelineResult_Resolve(t *testing.T) {
+	taskResults := map[string]map[string]string{
+		"build": {"digest": "sha256:1234", "url": "gcr.io/foo/bar"},
+		"scan":  {"report": "clean"},
+	}
+	tests := []struct {
+		name   string
+		result PipelineResult
+		want   string
+	}{{
+		name: "string result",
+		result: PipelineResult{
+			Name:  "image",
+			Value: *NewArrayOrString("$(tasks.build.results.url)@$(tasks.build.results.digest)"),
+		},
+		want: "gcr.io/foo/bar@sha256:1234",
+	}, {
+		name: "string result without references",
+		result: PipelineResult{
+			Name:  "constant",
+			Value: *NewArrayOrString("foo"),
+		},
+		want: "foo",
+	}, {
+		name: "array result aggregating task results",
+		result: PipelineResult{
+			Name:  "outputs",
+			Type:  ResultsTypeArray,
+			Value: *NewArrayOrString("$(tasks.build.results.digest)", "$(tasks.scan.results.report)"),
+		},
+		want: `["sha256:1234","clean"]`,
+	}, {
+		name: "object result",
+		result: PipelineResult{
+			Name: "summary",
+			Type: ResultsTypeObject,
+			Value: *NewObject(map[string]string{
+				"digest": "$(tasks.build.results.digest)",
+				"report": "$(tasks.scan.results.report)",
+			}),
+		},
+		want: `{"digest":"sha256:1234","report":"clean"}`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.result.Resolve(taskResults)
+			if err != nil {
+				t.Fatalf("PipelineResult.Resolve() returned unexpected error: %v", err)
+			}
+			if d := cmp.Diff(tt.want, got); d != "" {
+				t.Errorf("PipelineResult.Resolve() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPipelineResult_Resolve_Error(t *testing.T) {
+	taskResults := map[string]map[string]string{
+		"build": {"digest": "sha256:1234"},
+	}
+	tests := []struct {
+		name    string
+		result  PipelineResult
+		wantErr string
+	}{{
+		name: "unknown task",
+		result: PipelineResult{
+			Name:  "report",
+			Value: *NewArrayOrString("$(tasks.scan.results.report)"),
+		},
+		wantErr: `invalid pipelineresults [report], the referred results don't exist`,
+	}, {
+		name: "unknown result",
+		result: PipelineResult{
+			Name:  "outputs",
+			Type:  ResultsTypeArray,
+			Value: *NewArrayOrString("$(tasks.build.results.digest)", "$(tasks.build.results.url)"),
+		},
+		wantErr: `invalid pipelineresults [outputs], the referred results don't exist`,
+	}, {
+		name: "invalid reference",
+		result: PipelineResult{
+			Name:  "revision",
+			Value: *NewArrayOrString("$(params.revision)"),
+		},
+		wantErr: `invalid pipelineresults [revision], the referred results don't exist`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.result.Resolve(taskResults)
+			if err == nil {
+				t.Fatal("PipelineResult.Resolve() did not return an error")
+			}
+			if d := cmp.Diff(tt.wantErr, err.Error()); d != "" {
+				t.Errorf("PipelineResult.Resolve() error %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPipelineSpec_AggregateResults(t *testing.T) {
+	taskRunResults := map[string][]TaskRunResult{
+		"build": {{
+			Name:  "digest",
+			Value: *NewArrayOrString("sha256:1234"),
+		}, {
+			Name:  "url",
+			Value: *NewArrayOrString("gcr.io/foo/bar"),
+		}},
+		"scan": {{
+			Name:  "report",
+			Value: *NewArrayOrString("clean"),
+		}, {
+			Name:  "images",
+			Value: *NewArrayOrString("gcr.io/foo/bar", "gcr.io/foo/baz"),
+		}},
+	}
+	ps := &PipelineSpec{
+		Results: []PipelineResult{{
+			Name:  "image",
+			Value: *NewArrayOrString("$(tasks.build.results.url)@$(tasks.build.results.digest)"),
+		}, {
+			Name:  "images",
+			Type:  ResultsTypeArray,
+			Value: *NewArrayOrString("$(tasks.scan.results.images[*])"),
+		}, {
+			Name:  "outputs",
+			Type:  ResultsTypeArray,
+			Value: *NewArrayOrString("$(tasks.build.results.digest)", "$(tasks.scan.results.report)"),
+		}, {
+			Name: "summary",
+			Type: ResultsTypeObject,
+			Value: *NewObject(map[string]string{
+				"digest": "$(tasks.build.results.digest)",
+			}),
+		}},
+	}
+	want := []PipelineRunResult{{
+		Name:  "image",
+		Value: *NewArrayOrString("gcr.io/foo/bar@sha256:1234"),
+	}, {
+		Name:  "images",
+		Value: *NewArrayOrString("gcr.io/foo/bar", "gcr.io/foo/baz"),
+	}, {
+		Name:  "outputs",
+		Value: *NewArrayOrString("sha256:1234", "clean"),
+	}, {
+		Name:  "summary",
+		Value: *NewObject(map[string]string{"digest": "sha256:1234"}),
+	}}
+	got, err := ps.AggregateResults(taskRunResults)
+	if err != nil {
+		t.Fatalf("PipelineSpec.AggregateResults() returned unexpected error: %v", err)
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("PipelineSpec.AggregateResults() %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestPipelineSpec_AggregateResults_Error(t *testing.T) {
+	taskRunResults := map[string][]TaskRunResult{
+		"build": {{
+			Name:  "digest",
+			Value: *NewArrayOrString("sha256:1234"),
+		}},
+	}
+	ps := &PipelineSpec{
+		Results: []PipelineResult{{
+			Name:  "digest",
+			Value: *NewArrayOrString("$(tasks.build.results.digest)"),
+		}, {
+			Name:  "report",
+			Value: *NewArrayOrString("$(tasks.scan.results.report)"),
+		}, {
+			Name:  "images",
+			Type:  ResultsTypeArray,
+			Value: *NewArrayOrString("$(tasks.build.results.images[*])"),
+		}},
+	}
+	// like in the PipelineRun status, the results that can't be resolved are left out
+	want := []PipelineRunResult{{
+		Name:  "digest",
+		Value: *NewArrayOrString("sha256:1234"),
+	}}
+	wantErr := `invalid pipelineresults [report images], the referred results don't exist`
+	got, err := ps.AggregateResults(taskRunResults)
+	if err == nil {
+		t.Fatal("PipelineSpec.AggregateResults() did not return an error")
+	}
+	if d := cmp.Diff(wantErr, err.Error()); d != "" {
+		t.Errorf("PipelineSpec.AggregateResults() error %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("PipelineSpec.AggregateResults() %s", diff.PrintWantGot(d))
+	}
+}
@@ -122,6 +122,93 @@ func validatePipelineWorkspacesUsage(wss []PipelineWorkspaceDeclaration, pts []P
 	return errs
 }
 
+// ValidateWorkspaceUsage returns an error if any of the PipelineSpec's Tasks or Finally Tasks binds a
+// workspace that isn't declared by the PipelineSpec.
+func (ps *PipelineSpec) ValidateWorkspaceUsage() error {
+	errs := validatePipelineWorkspacesUsage(ps.Workspaces, ps.Tasks).ViaField("tasks")
+	errs = errs.Also(validatePipelineWorkspacesUsage(ps.Workspaces, ps.Finally).ViaField("finally"))
+	if errs == nil {
+		return nil
+	}
+	return errs
+}
+
+// ValidateWorkspaceReadOnly returns an error if a volume that the given bindings realize the pipeline workspaces
+// with is bound to a workspace declared readOnly by one of the PipelineSpec's Tasks or Finally Tasks and to a
+// writable one by another, since they'd be sharing it with inconsistent expectations. Only volumes shared between
+// Tasks are checked: the PersistentVolumeClaims of the bindings, which several pipeline workspaces can bind, and
+// the claims created from their VolumeClaimTemplates. Only Tasks with an embedded TaskSpec are checked, as the
+// declarations of referenced Tasks aren't known.
+func (ps *PipelineSpec) ValidateWorkspaceReadOnly(bindings []WorkspaceBinding) error {
+	volumes := map[string]string{}
+	for _, b := range bindings {
+		switch {
+		case b.PersistentVolumeClaim != nil:
+			volumes[b.Name] = fmt.Sprintf("persistent volume claim %q", b.PersistentVolumeClaim.ClaimName)
+		case b.VolumeClaimTemplate != nil:
+			volumes[b.Name] = fmt.Sprintf("the volume claim template of pipeline workspace %q", b.Name)
+		}
+	}
+
+	type usage struct {
+		taskName string
+		readOnly bool
+	}
+	firstUsages := map[string]usage{}
+	var errs *apis.FieldError
+	for _, section := range []struct {
+		field string
+		tasks []PipelineTask
+	}{{"tasks", ps.Tasks}, {"finally", ps.Finally}} {
+		for i, pt := range section.tasks {
+			if pt.TaskSpec == nil {
+				continue
+			}
+			declarations := map[string]WorkspaceDeclaration{}
+			for _, wd := range pt.TaskSpec.Workspaces {
+				declarations[wd.Name] = wd
+			}
+			for j, ws := range pt.Workspaces {
+				declaration, ok := declarations[ws.Name]
+				if !ok {
+					continue
+				}
+				workspace := ws.Workspace
+				if workspace == "" {
+					workspace = ws.Name
+				}
+				volume, ok := volumes[workspace]
+				if !ok {
+					continue
+				}
+				first, ok := firstUsages[volume]
+				if !ok {
+					firstUsages[volume] = usage{taskName: pt.Name, readOnly: declaration.ReadOnly}
+					continue
+				}
+				if first.readOnly != declaration.ReadOnly {
+					errs = errs.Also(apis.ErrInvalidValue(
+						fmt.Sprintf("%s is bound to a %s workspace by pipeline task %q but to a %s workspace by pipeline task %q",
+							volume, readOnlyMode(declaration.ReadOnly), pt.Name, readOnlyMode(first.readOnly), first.taskName),
+						"",
+					).ViaFieldIndex("workspaces", j).ViaFieldIndex(section.field, i))
+				}
+			}
+		}
+	}
+	if errs == nil {
+		return nil
+	}
+	return errs
+}
+
+func readOnlyMode(readOnly bool) string {
+	if readOnly {
+		return "readOnly"
+	}
+	return "writable"
+}
+
 // validatePipelineParameterVariables validates parameters with those specified by each pipeline task,
 // (1) it validates the type of parameter is either string or array (2) parameter default value matches
 // with the type of that param (3) ensures that the referenced param variable is defined is part of the param declarations
@@ -319,6 +406,15 @@ func validateTasksAndFinallySection(ps *PipelineSpec) *apis.FieldError {
 	return nil
 }
 
+// ValidateFinally returns an error if any of the PipelineSpec's Finally Tasks uses runAfter, or references
+// the results of another Finally Task or of a Task that isn't in the PipelineSpec.
+func (ps *PipelineSpec) ValidateFinally() error {
+	if errs := validateFinalTasks(ps.Tasks, ps.Finally); errs != nil {
+		return errs
+	}
+	return nil
+}
+
 func validateFinalTasks(tasks []PipelineTask, finalTasks []PipelineTask) (errs *apis.FieldError) {
 	for idx, f := range finalTasks {
 		if len(f.RunAfter) != 0 {
@@ -494,6 +590,235 @@ func validateGraph(tasks []PipelineTask) *apis.FieldError {
 	return nil
 }
 
+// ValidateDAG builds the dependency graph (DAG) of the PipelineSpec's Tasks and returns an error naming
+// the Tasks involved if there is a dependency cycle or a dependency on a Task that isn't in the Pipeline.
+func (ps *PipelineSpec) ValidateDAG() error {
+	_, err := dag.Build(PipelineTaskList(ps.Tasks), PipelineTaskList(ps.Tasks).Deps())
+	return err
+}
+
+// ValidateResultReferences checks that the result references in the params, when expressions and matrices
+// of the PipelineSpec's Tasks and Finally Tasks refer to one of its Tasks and, if that Task is embedded, to a
+// result it declares. The results of referenced Tasks are only known at run time and aren't checked.
+func (ps *PipelineSpec) ValidateResultReferences() error {
+	var errs *apis.FieldError
+	tasks := map[string]*PipelineTask{}
+	for i := range ps.Tasks {
+		tasks[ps.Tasks[i].Name] = &ps.Tasks[i]
+	}
+
+	validateRefs := func(pts []PipelineTask, field string) {
+		for i := range pts {
+			for _, ref := range PipelineTaskResultRefs(&pts[i]) {
+				referenced, ok := tasks[ref.PipelineTask]
+				if !ok {
+					errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("result reference to unknown pipeline task %q", ref.PipelineTask), apis.CurrentField).ViaFieldIndex(field, i))
+					continue
+				}
+				if spec, _, isEmbedded := referenced.TaskSpecOrRef(); isEmbedded && !declaresResult(spec.TaskSpec, ref.Result) {
+					errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("pipeline task %q does not declare result %q", ref.PipelineTask, ref.Result), apis.CurrentField).ViaFieldIndex(field, i))
+				}
+			}
+		}
+	}
+	validateRefs(ps.Tasks, "tasks")
+	validateRefs(ps.Finally, "finally")
+
+	if errs == nil {
+		return nil
+	}
+	return errs
+}
+
+func declaresResult(ts TaskSpec, name string) bool {
+	for _, r := range ts.Results {
+		if r.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateMatrixResultReferences checks that the results of the PipelineSpec's matrixed Tasks are only
+// referenced as a whole, as in $(tasks.<task>.results.<result>[*]), from the params, when expressions and
+// matrices of its Tasks and Finally Tasks and from its results. A matrixed Task produces an array of
+// values for each of its results, so a reference to a single value of one is invalid.
+func (ps *PipelineSpec) ValidateMatrixResultReferences() error {
+	matrixed := sets.NewString()
+	for _, pt := range ps.Tasks {
+		if len(pt.Matrix) != 0 {
+			matrixed.Insert(pt.Name)
+		}
+	}
+	var errs *apis.FieldError
+	validateExpressions := func(expressions []string, field string, i int) {
+		for _, expression := range expressions {
+			for _, ref := range NewResultRefs([]string{expression}) {
+				if matrixed.Has(ref.PipelineTask) && !strings.HasSuffix(expression, "[*]") {
+					errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("result reference $(%s) to matrixed pipeline task %q must be an array reference ending in [*]",
+						expression, ref.PipelineTask), apis.CurrentField).ViaFieldIndex(field, i))
+				}
+			}
+		}
+	}
+	validateTasks := func(pts []PipelineTask, field string) {
+		for i := range pts {
+			for _, p := range append(append([]Param{}, pts[i].Params...), pts[i].Matrix...) {
+				expressions, _ := GetVarSubstitutionExpressionsForParam(p)
+				validateExpressions(expressions, field, i)
+			}
+			for _, we := range pts[i].WhenExpressions {
+				expressions, _ := we.GetVarSubstitutionExpressions()
+				validateExpressions(expressions, field, i)
+			}
+		}
+	}
+	if len(matrixed) != 0 {
+		validateTasks(ps.Tasks, "tasks")
+		validateTasks(ps.Finally, "finally")
+		for i, r := range ps.Results {
+			expressions, _ := GetVarSubstitutionExpressionsForPipelineResult(r)
+			validateExpressions(expressions, "results", i)
+		}
+	}
+
+	if errs == nil {
+		return nil
+	}
+	return errs
+}
+
+// ValidateParams checks the params provided for a run of the PipelineSpec, e.g. by a PipelineRun, against
+// the params it declares. Params that aren't declared, declared params without a default that aren't
+// provided, and params whose type doesn't match the declared type are all reported in one error. Declared
+// types are expected to have been defaulted already.
+func (ps *PipelineSpec) ValidateParams(provided []Param) error {
+	var errs *apis.FieldError
+	declared := map[string]ParamSpec{}
+	for _, p := range ps.Params {
+		declared[p.Name] = p
+	}
+
+	providedNames := sets.NewString()
+	for i, p := range provided {
+		providedNames.Insert(p.Name)
+		spec, ok := declared[p.Name]
+		if !ok {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("param %q is not declared by the pipeline", p.Name), "name").ViaFieldIndex("params", i))
+			continue
+		}
+		if spec.Type != "" && p.Value.Type != spec.Type {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s, param %q is declared with type %s", p.Value.Type, p.Name, spec.Type), "value").ViaFieldIndex("params", i))
+		}
+	}
+
+	var missing []string
+	for _, p := range ps.Params {
+		if p.Default == nil && !providedNames.Has(p.Name) {
+			missing = append(missing, p.Name)
+		}
+	}
+	if len(missing) != 0 {
+		errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("missing required params: %s", strings.Join(missing, ", ")), "params"))
+	}
+
+	if errs == nil {
+		return nil
+	}
+	return errs
+}
+
+// ValidatePropagatedParamTypes returns an error if a param is referenced by the PipelineSpec's Tasks or Finally
+// Tasks in a way that doesn't match its type, e.g. an array param in a string or a string param as $(params.foo[*]).
+// The params that are provided without being declared by the PipelineSpec, to be propagated, are typed by
+// Param.InferredType, the declared ones by their ParamSpecs.
+func (ps *PipelineSpec) ValidatePropagatedParamTypes(provided []Param) error {
+	params := make([]ParamSpec, 0, len(ps.Params)+len(provided))
+	declared := sets.NewString()
+	for _, p := range ps.Params {
+		declared.Insert(p.Name)
+		params = append(params, p)
+	}
+	for _, p := range provided {
+		if declared.Has(p.Name) {
+			continue
+		}
+		spec := ParamSpec{Name: p.Name, Type: p.InferredType()}
+		for key := range p.Value.ObjectVal {
+			if spec.Properties == nil {
+				spec.Properties = map[string]PropertySpec{}
+			}
+			spec.Properties[key] = PropertySpec{Type: ParamTypeString}
+		}
+		declared.Insert(p.Name)
+		params = append(params, spec)
+	}
+
+	paramNames := sets.NewString()
+	arrayParamNames := sets.NewString()
+	objectParamNameKeys := map[string][]string{}
+	for _, p := range params {
+		paramNames.Insert(p.Name)
+		switch p.Type {
+		case ParamTypeArray:
+			arrayParamNames.Insert(p.Name)
+		case ParamTypeObject:
+			for key := range p.Properties {
+				objectParamNameKeys[p.Name] = append(objectParamNameKeys[p.Name], key)
+			}
+		}
+	}
+	stringParamNames := paramNames.Difference(arrayParamNames).Difference(sets.StringKeySet(objectParamNameKeys))
+	errs := validatePipelineParametersVariables(ps.Tasks, "params", paramNames, arrayParamNames, objectParamNameKeys).ViaField("tasks")
+	errs = errs.Also(validateStringParamsNotIndexed(ps.Tasks, stringParamNames).ViaField("tasks"))
+	errs = errs.Also(validatePipelineParametersVariables(ps.Finally, "params", paramNames, arrayParamNames, objectParamNameKeys).ViaField("finally"))
+	errs = errs.Also(validateStringParamsNotIndexed(ps.Finally, stringParamNames).ViaField("finally"))
+	if errs == nil {
+		return nil
+	}
+	return errs
+}
+
+// validateStringParamsNotIndexed returns an error for each reference to one of the string params as an array,
+// $(params.foo[*]) or $(params.foo[0]), in the params, matrix and when expressions of the PipelineTasks.
+func validateStringParamsNotIndexed(tasks []PipelineTask, stringParamNames sets.String) (errs *apis.FieldError) {
+	for idx, task := range tasks {
+		for _, section := range []struct {
+			field  string
+			params []Param
+		}{{"params", task.Params}, {"matrix", task.Matrix}} {
+			for _, param := range section.params {
+				values := append([]string{param.Value.StringVal}, param.Value.ArrayVal...)
+				for _, v := range param.Value.ObjectVal {
+					values = append(values, v)
+				}
+				for _, value := range values {
+					errs = errs.Also(validateStringParamNotIndexed(value, stringParamNames).ViaFieldKey(section.field, param.Name).ViaIndex(idx))
+				}
+			}
+		}
+		for i, we := range task.WhenExpressions {
+			for _, value := range append([]string{we.Input}, we.Values...) {
+				errs = errs.Also(validateStringParamNotIndexed(value, stringParamNames).ViaFieldIndex("when", i).ViaIndex(idx))
+			}
+		}
+	}
+	return errs
+}
+
+func validateStringParamNotIndexed(value string, stringParamNames sets.String) (errs *apis.FieldError) {
+	for _, expression := range validateString(value) {
+		if !strings.HasPrefix(expression, ParamsPrefix+".") {
+			continue
+		}
+		reference := strings.TrimPrefix(expression, ParamsPrefix+".")
+		if name := substitution.TrimArrayIndex(reference); name != reference && stringParamNames.Has(name) {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("string param %q is referenced as an array in %q", name, value), ""))
+		}
+	}
+	return errs
+}
+
 func validateMatrix(ctx context.Context, tasks []PipelineTask) (errs *apis.FieldError) {
 	for idx, task := range tasks {
 		errs = errs.Also(task.validateMatrix(ctx).ViaIndex(idx))
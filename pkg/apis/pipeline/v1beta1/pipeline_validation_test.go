@@ -30,6 +30,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"knative.dev/pkg/apis"
 	logtesting "knative.dev/pkg/logging/testing"
 )
@@ -1092,6 +1093,611 @@ func TestValidateGraph_Failure(t *testing.T) {
 	}
 }
 
+func TestPipelineSpec_ValidateDAG(t *testing.T) {
+	tests := []struct {
+		name     string
+		tasks    []PipelineTask
+		wantErrs []string
+	}{{
+		name: "valid diamond DAG",
+		tasks: []PipelineTask{{
+			Name: "a", TaskRef: &TaskRef{Name: "task"},
+		}, {
+			Name: "b", TaskRef: &TaskRef{Name: "task"}, RunAfter: []string{"a"},
+		}, {
+			Name: "c", TaskRef: &TaskRef{Name: "task"},
+			Params: []Param{{
+				Name: "param", Value: ArrayOrString{Type: ParamTypeString, StringVal: "$(tasks.a.results.result)"},
+			}},
+		}, {
+			Name: "d", TaskRef: &TaskRef{Name: "task"}, RunAfter: []string{"b", "c"},
+		}},
+	}, {
+		name: "cycle between two tasks",
+		tasks: []PipelineTask{{
+			Name: "a", TaskRef: &TaskRef{Name: "task"}, RunAfter: []string{"b"},
+		}, {
+			Name: "b", TaskRef: &TaskRef{Name: "task"},
+			Params: []Param{{
+				Name: "param", Value: ArrayOrString{Type: ParamTypeString, StringVal: "$(tasks.a.results.result)"},
+			}},
+		}},
+		// the tasks are linked in map order, so the cycle can be reported starting from either task
+		wantErrs: []string{
+			"couldn't add link between b and a: couldn't create link from a to b: cycle detected: b -> a -> b",
+			"couldn't add link between a and b: couldn't create link from b to a: cycle detected: a -> b -> a",
+		},
+	}, {
+		name: "self-loop via runAfter",
+		tasks: []PipelineTask{{
+			Name: "a", TaskRef: &TaskRef{Name: "task"}, RunAfter: []string{"a"},
+		}},
+		wantErrs: []string{`couldn't add link between a and a: couldn't create link from a to a: cycle detected; task "a" depends on itself`},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ps := &PipelineSpec{Tasks: tt.tasks}
+			err := ps.ValidateDAG()
+			if len(tt.wantErrs) == 0 {
+				if err != nil {
+					t.Errorf("PipelineSpec.ValidateDAG() returned error for valid DAG: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("PipelineSpec.ValidateDAG() did not return error for invalid DAG")
+			}
+			if !sets.NewString(tt.wantErrs...).Has(err.Error()) {
+				t.Errorf("PipelineSpec.ValidateDAG() error = %q, want one of %q", err, tt.wantErrs)
+			}
+		})
+	}
+}
+
+func TestPipelineSpec_Validate_ParamEnum(t *testing.T) {
+	ps := &PipelineSpec{
+		Params: []ParamSpec{{
+			Name: "mode", Type: ParamTypeString, Enum: []string{"fast", "slow"}, Default: NewArrayOrString("fast"),
+		}},
+		Tasks: []PipelineTask{{
+			Name:    "foo",
+			TaskRef: &TaskRef{Name: "foo-task"},
+			Params: []Param{{
+				Name: "mode", Value: *NewArrayOrString("$(params.mode)"),
+			}},
+		}},
+	}
+	if err := ps.Validate(config.EnableAlphaAPIFields(context.Background())); err != nil {
+		t.Errorf("PipelineSpec.Validate() returned error with alpha fields enabled: %v", err)
+	}
+	err := ps.Validate(context.Background())
+	want := `enum requires "enable-api-fields" feature gate to be "alpha" but it is "stable": `
+	if err == nil {
+		t.Fatal("PipelineSpec.Validate() did not return error with alpha fields disabled")
+	}
+	if d := cmp.Diff(want, err.Error()); d != "" {
+		t.Errorf("PipelineSpec.Validate() %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestPipelineSpec_ValidateResultReferences(t *testing.T) {
+	embedded := &EmbeddedTask{TaskSpec: TaskSpec{
+		Steps:   []Step{{Name: "foo", Image: "bar"}},
+		Results: []TaskResult{{Name: "commit"}},
+	}}
+	tests := []struct {
+		name    string
+		ps      *PipelineSpec
+		wantErr string
+	}{{
+		name: "valid references",
+		ps: &PipelineSpec{
+			Tasks: []PipelineTask{{
+				Name: "clone", TaskSpec: embedded,
+			}, {
+				Name: "lint", TaskRef: &TaskRef{Name: "lint"},
+			}, {
+				Name: "build", TaskRef: &TaskRef{Name: "build"},
+				Params: []Param{{
+					Name: "commit", Value: *NewArrayOrString("$(tasks.clone.results.commit)"),
+				}},
+				WhenExpressions: WhenExpressions{{
+					// lint isn't embedded so its results are only known at run time
+					Input: "$(tasks.lint.results.status)", Operator: selection.In, Values: []string{"passed"},
+				}},
+			}},
+			Finally: []PipelineTask{{
+				Name: "report", TaskRef: &TaskRef{Name: "report"},
+				Matrix: []Param{{
+					Name: "commit", Value: *NewArrayOrString("$(tasks.clone.results.commit)"),
+				}},
+			}},
+		},
+	}, {
+		name: "reference to a missing task",
+		ps: &PipelineSpec{
+			Tasks: []PipelineTask{{
+				Name: "build", TaskRef: &TaskRef{Name: "build"},
+				Params: []Param{{
+					Name: "commit", Value: *NewArrayOrString("$(tasks.clone.results.commit)"),
+				}},
+			}},
+		},
+		wantErr: `result reference to unknown pipeline task "clone": tasks[0]`,
+	}, {
+		name: "reference to an undeclared result",
+		ps: &PipelineSpec{
+			Tasks: []PipelineTask{{
+				Name: "clone", TaskSpec: embedded,
+			}},
+			Finally: []PipelineTask{{
+				Name: "report", TaskRef: &TaskRef{Name: "report"},
+				WhenExpressions: WhenExpressions{{
+					Input: "$(tasks.clone.results.url)", Operator: selection.NotIn, Values: []string{""},
+				}},
+			}},
+		},
+		wantErr: `pipeline task "clone" does not declare result "url": finally[0]`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.ps.ValidateResultReferences()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("PipelineSpec.ValidateResultReferences() returned error for valid references: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("PipelineSpec.ValidateResultReferences() did not return error for invalid references")
+			}
+			if d := cmp.Diff(tt.wantErr, err.Error()); d != "" {
+				t.Errorf("PipelineSpec.ValidateResultReferences() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPipelineSpec_ValidateMatrixResultReferences(t *testing.T) {
+	build := PipelineTask{
+		Name: "build", TaskRef: &TaskRef{Name: "build"},
+		Matrix: []Param{{
+			Name: "platform", Value: *NewArrayOrString("linux", "mac"),
+		}},
+	}
+	tests := []struct {
+		name    string
+		ps      *PipelineSpec
+		wantErr string
+	}{{
+		name: "array reference",
+		ps: &PipelineSpec{
+			Tasks: []PipelineTask{build, {
+				Name: "publish", TaskRef: &TaskRef{Name: "publish"},
+				Params: []Param{{
+					Name: "digests", Value: *NewArrayOrString("$(tasks.build.results.digest[*])"),
+				}},
+			}},
+			Results: []PipelineResult{{
+				Name: "digests", Value: *NewArrayOrString("$(tasks.build.results.digest[*])"),
+			}},
+		},
+	}, {
+		name: "scalar reference to a task that isn't matrixed",
+		ps: &PipelineSpec{
+			Tasks: []PipelineTask{build, {
+				Name: "clone", TaskRef: &TaskRef{Name: "clone"},
+			}, {
+				Name: "publish", TaskRef: &TaskRef{Name: "publish"},
+				Params: []Param{{
+					Name: "commit", Value: *NewArrayOrString("$(tasks.clone.results.commit)"),
+				}},
+			}},
+		},
+	}, {
+		name: "scalar reference",
+		ps: &PipelineSpec{
+			Tasks: []PipelineTask{build},
+			Finally: []PipelineTask{{
+				Name: "report", TaskRef: &TaskRef{Name: "report"},
+				WhenExpressions: WhenExpressions{{
+					Input: "$(tasks.build.results.status)", Operator: selection.In, Values: []string{"passed"},
+				}},
+			}},
+			Results: []PipelineResult{{
+				Name: "digest", Value: *NewArrayOrString("$(tasks.build.results.digest)"),
+			}},
+		},
+		wantErr: `result reference $(tasks.build.results.digest) to matrixed pipeline task "build" must be an array reference ending in [*]: results[0]
+result reference $(tasks.build.results.status) to matrixed pipeline task "build" must be an array reference ending in [*]: finally[0]`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.ps.ValidateMatrixResultReferences()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("PipelineSpec.ValidateMatrixResultReferences() returned error for valid references: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("PipelineSpec.ValidateMatrixResultReferences() did not return error for invalid references")
+			}
+			if d := cmp.Diff(tt.wantErr, err.Error()); d != "" {
+				t.Errorf("PipelineSpec.ValidateMatrixResultReferences() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPipelineSpec_ValidateWorkspaceUsage(t *testing.T) {
+	tests := []struct {
+		name    string
+		ps      *PipelineSpec
+		wantErr string
+	}{{
+		name: "declared workspaces",
+		ps: &PipelineSpec{
+			Workspaces: []PipelineWorkspaceDeclaration{{Name: "shared"}, {Name: "cache"}},
+			Tasks: []PipelineTask{{
+				Name: "build", TaskRef: &TaskRef{Name: "build"},
+				Workspaces: []WorkspacePipelineTaskBinding{{Name: "source", Workspace: "shared"}, {Name: "cache"}},
+			}},
+			Finally: []PipelineTask{{
+				Name: "report", TaskRef: &TaskRef{Name: "report"},
+				Workspaces: []WorkspacePipelineTaskBinding{{Name: "output", Workspace: "shared"}},
+			}},
+		},
+	}, {
+		name: "undeclared workspace",
+		ps: &PipelineSpec{
+			Workspaces: []PipelineWorkspaceDeclaration{{Name: "shared"}},
+			Finally: []PipelineTask{{
+				Name: "report", TaskRef: &TaskRef{Name: "report"},
+				Workspaces: []WorkspacePipelineTaskBinding{{Name: "output", Workspace: "results"}},
+			}},
+		},
+		wantErr: `invalid value: pipeline task "report" expects workspace with name "results" but none exists in pipeline spec: finally[0].workspaces[0]`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.ps.ValidateWorkspaceUsage()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("PipelineSpec.ValidateWorkspaceUsage() returned error for valid workspaces: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("PipelineSpec.ValidateWorkspaceUsage() did not return error for undeclared workspace")
+			}
+			if d := cmp.Diff(tt.wantErr, err.Error()); d != "" {
+				t.Errorf("PipelineSpec.ValidateWorkspaceUsage() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPipelineSpec_ValidateWorkspaceReadOnly(t *testing.T) {
+	embeddedTask := func(readOnly bool) *EmbeddedTask {
+		return &EmbeddedTask{TaskSpec: TaskSpec{
+			Steps:      []Step{{Name: "run", Image: "busybox"}},
+			Workspaces: []WorkspaceDeclaration{{Name: "source", ReadOnly: readOnly}},
+		}}
+	}
+	pvc := func(name, claimName string) WorkspaceBinding {
+		return WorkspaceBinding{
+			Name:                  name,
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: claimName},
+		}
+	}
+	tests := []struct {
+		name     string
+		ps       *PipelineSpec
+		bindings []WorkspaceBinding
+		wantErr  string
+	}{{
+		name: "consistent declarations",
+		ps: &PipelineSpec{
+			Workspaces: []PipelineWorkspaceDeclaration{{Name: "shared"}, {Name: "cache"}},
+			Tasks: []PipelineTask{{
+				Name: "lint", TaskSpec: embeddedTask(true),
+				Workspaces: []WorkspacePipelineTaskBinding{{Name: "source", Workspace: "shared"}},
+			}, {
+				Name: "test", TaskSpec: embeddedTask(true),
+				Workspaces: []WorkspacePipelineTaskBinding{{Name: "source", Workspace: "shared"}},
+			}, {
+				Name: "build", TaskSpec: embeddedTask(false),
+				Workspaces: []WorkspacePipelineTaskBinding{{Name: "source", Workspace: "cache"}},
+			}, {
+				Name: "push", TaskRef: &TaskRef{Name: "push"},
+				Workspaces: []WorkspacePipelineTaskBinding{{Name: "source", Workspace: "shared"}},
+			}},
+		},
+		bindings: []WorkspaceBinding{pvc("shared", "shared-pvc"), pvc("cache", "cache-pvc")},
+	}, {
+		name: "conflicting declarations",
+		ps: &PipelineSpec{
+			Workspaces: []PipelineWorkspaceDeclaration{{Name: "source"}},
+			Tasks: []PipelineTask{{
+				Name: "lint", TaskSpec: embeddedTask(true),
+				Workspaces: []WorkspacePipelineTaskBinding{{Name: "source"}},
+			}},
+			Finally: []PipelineTask{{
+				Name: "cleanup", TaskSpec: embeddedTask(false),
+				Workspaces: []WorkspacePipelineTaskBinding{{Name: "source"}},
+			}},
+		},
+		bindings: []WorkspaceBinding{pvc("source", "my-pvc")},
+		wantErr:  `invalid value: persistent volume claim "my-pvc" is bound to a writable workspace by pipeline task "cleanup" but to a readOnly workspace by pipeline task "lint": finally[0].workspaces[0]`,
+	}, {
+		name: "two pipeline workspaces bound to the same claim",
+		ps: &PipelineSpec{
+			Workspaces: []PipelineWorkspaceDeclaration{{Name: "sources"}, {Name: "output"}},
+			Tasks: []PipelineTask{{
+				Name: "lint", TaskSpec: embeddedTask(true),
+				Workspaces: []WorkspacePipelineTaskBinding{{Name: "source", Workspace: "sources"}},
+			}, {
+				Name: "build", TaskSpec: embeddedTask(false),
+				Workspaces: []WorkspacePipelineTaskBinding{{Name: "source", Workspace: "output"}},
+			}},
+		},
+		bindings: []WorkspaceBinding{pvc("sources", "my-pvc"), pvc("output", "my-pvc")},
+		wantErr:  `invalid value: persistent volume claim "my-pvc" is bound to a writable workspace by pipeline task "build" but to a readOnly workspace by pipeline task "lint": tasks[1].workspaces[0]`,
+	}, {
+		name: "conflicting declarations of a volume claim template",
+		ps: &PipelineSpec{
+			Workspaces: []PipelineWorkspaceDeclaration{{Name: "source"}},
+			Tasks: []PipelineTask{{
+				Name: "lint", TaskSpec: embeddedTask(true),
+				Workspaces: []WorkspacePipelineTaskBinding{{Name: "source"}},
+			}, {
+				Name: "build", TaskSpec: embeddedTask(false),
+				Workspaces: []WorkspacePipelineTaskBinding{{Name: "source"}},
+			}},
+		},
+		bindings: []WorkspaceBinding{{Name: "source", VolumeClaimTemplate: &corev1.PersistentVolumeClaim{}}},
+		wantErr:  `invalid value: the volume claim template of pipeline workspace "source" is bound to a writable workspace by pipeline task "build" but to a readOnly workspace by pipeline task "lint": tasks[1].workspaces[0]`,
+	}, {
+		name: "conflicting declarations of a volume that isn't shared",
+		ps: &PipelineSpec{
+			Workspaces: []PipelineWorkspaceDeclaration{{Name: "source"}},
+			Tasks: []PipelineTask{{
+				Name: "lint", TaskSpec: embeddedTask(true),
+				Workspaces: []WorkspacePipelineTaskBinding{{Name: "source"}},
+			}, {
+				Name: "build", TaskSpec: embeddedTask(false),
+				Workspaces: []WorkspacePipelineTaskBinding{{Name: "source"}},
+			}},
+		},
+		bindings: []WorkspaceBinding{{Name: "source", EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.ps.ValidateWorkspaceReadOnly(tt.bindings)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("PipelineSpec.ValidateWorkspaceReadOnly() returned error for consistent workspaces: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("PipelineSpec.ValidateWorkspaceReadOnly() did not return error for conflicting workspaces")
+			}
+			if d := cmp.Diff(tt.wantErr, err.Error()); d != "" {
+				t.Errorf("PipelineSpec.ValidateWorkspaceReadOnly() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPipelineSpec_ValidateFinally(t *testing.T) {
+	tasks := []PipelineTask{{
+		Name: "build", TaskRef: &TaskRef{Name: "build"},
+	}}
+	tests := []struct {
+		name    string
+		finally []PipelineTask
+		wantErr string
+	}{{
+		name: "finally task references a task result",
+		finally: []PipelineTask{{
+			Name: "report", TaskRef: &TaskRef{Name: "report"},
+			Params: []Param{{
+				Name: "image", Value: *NewArrayOrString("$(tasks.build.results.image)"),
+			}},
+		}},
+	}, {
+		name: "finally task with runAfter",
+		finally: []PipelineTask{{
+			Name: "report", TaskRef: &TaskRef{Name: "report"}, RunAfter: []string{"build"},
+		}},
+		wantErr: "invalid value: no runAfter allowed under spec.finally, final task report has runAfter specified: finally[0]",
+	}, {
+		name: "finally task references a finally task result",
+		finally: []PipelineTask{{
+			Name: "cleanup", TaskRef: &TaskRef{Name: "cleanup"},
+		}, {
+			Name: "report", TaskRef: &TaskRef{Name: "report"},
+			Params: []Param{{
+				Name: "status", Value: *NewArrayOrString("$(tasks.cleanup.results.status)"),
+			}},
+		}},
+		wantErr: "invalid value: invalid task result reference, final task has task result reference from a final task cleanup: finally[1].params[status].value",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ps := &PipelineSpec{Tasks: tasks, Finally: tt.finally}
+			err := ps.ValidateFinally()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("PipelineSpec.ValidateFinally() returned error for valid finally tasks: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("PipelineSpec.ValidateFinally() did not return error for invalid finally tasks")
+			}
+			if d := cmp.Diff(tt.wantErr, err.Error()); d != "" {
+				t.Errorf("PipelineSpec.ValidateFinally() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPipelineSpec_ValidateParams(t *testing.T) {
+	ps := &PipelineSpec{
+		Params: []ParamSpec{{
+			Name: "url", Type: ParamTypeString,
+		}, {
+			Name: "revision", Type: ParamTypeString, Default: NewArrayOrString("main"),
+		}, {
+			Name: "flags", Type: ParamTypeArray,
+		}},
+	}
+	tests := []struct {
+		name     string
+		provided []Param
+		wantErr  string
+	}{{
+		name: "all required params provided",
+		provided: []Param{{
+			Name: "url", Value: *NewArrayOrString("https://example.com"),
+		}, {
+			Name: "flags", Value: *NewArrayOrString("-v", "-x"),
+		}},
+	}, {
+		name: "missing required param",
+		provided: []Param{{
+			Name: "url", Value: *NewArrayOrString("https://example.com"),
+		}},
+		wantErr: "missing required params: flags: params",
+	}, {
+		name: "unknown param",
+		provided: []Param{{
+			Name: "url", Value: *NewArrayOrString("https://example.com"),
+		}, {
+			Name: "flags", Value: *NewArrayOrString("-v", "-x"),
+		}, {
+			Name: "unknown", Value: *NewArrayOrString("foo"),
+		}},
+		wantErr: `param "unknown" is not declared by the pipeline: params[2].name`,
+	}, {
+		name: "array supplied for string param",
+		provided: []Param{{
+			Name: "url", Value: *NewArrayOrString("https://example.com", "https://example.org"),
+		}, {
+			Name: "flags", Value: *NewArrayOrString("-v", "-x"),
+		}},
+		wantErr: `invalid value: array, param "url" is declared with type string: params[0].value`,
+	}, {
+		name: "all problems are reported together",
+		provided: []Param{{
+			Name: "url", Value: *NewArrayOrString("https://example.com", "https://example.org"),
+		}, {
+			Name: "unknown", Value: *NewArrayOrString("foo"),
+		}},
+		wantErr: `invalid value: array, param "url" is declared with type string: params[0].value
+missing required params: flags: params
+param "unknown" is not declared by the pipeline: params[1].name`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ps.ValidateParams(tt.provided)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("PipelineSpec.ValidateParams() returned error for valid params: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("PipelineSpec.ValidateParams() did not return error for invalid params")
+			}
+			if d := cmp.Diff(tt.wantErr, err.Error()); d != "" {
+				t.Errorf("PipelineSpec.ValidateParams() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPipelineSpec_ValidatePropagatedParamTypes(t *testing.T) {
+	provided := []Param{{
+		Name: "revision", Value: *NewArrayOrString("main"),
+	}, {
+		Name: "flags", Value: *NewArrayOrString("-v", "-x"),
+	}}
+	tests := []struct {
+		name    string
+		ps      *PipelineSpec
+		wantErr string
+	}{{
+		name: "propagated params referenced as their types",
+		ps: &PipelineSpec{
+			Params: []ParamSpec{{Name: "url", Type: ParamTypeString}},
+			Tasks: []PipelineTask{{
+				Name: "build", TaskRef: &TaskRef{Name: "build"},
+				Params: []Param{{
+					Name: "ref", Value: *NewArrayOrString("$(params.url)#$(params.revision)"),
+				}, {
+					Name: "args", Value: *NewArrayOrString("$(params.flags[*])", "-o"),
+				}, {
+					Name: "all-args", Value: *NewArrayOrString("$(params.flags[*])"),
+				}},
+			}},
+		},
+	}, {
+		name: "array param used as string",
+		ps: &PipelineSpec{
+			Tasks: []PipelineTask{{
+				Name: "build", TaskRef: &TaskRef{Name: "build"},
+				Params: []Param{{
+					Name: "args", Value: *NewArrayOrString("--flags=$(params.flags)"),
+				}},
+			}},
+		},
+		wantErr: `variable type invalid in "--flags=$(params.flags)": tasks[0].params[args]`,
+	}, {
+		name: "string param used as array star",
+		ps: &PipelineSpec{
+			Finally: []PipelineTask{{
+				Name: "report", TaskRef: &TaskRef{Name: "report"},
+				Params: []Param{{
+					Name: "revisions", Value: *NewArrayOrString("$(params.revision[*])", "latest"),
+				}},
+			}},
+		},
+		wantErr: `string param "revision" is referenced as an array in "$(params.revision[*])": finally[0].params[revisions]`,
+	}, {
+		name: "string param element used in when expression",
+		ps: &PipelineSpec{
+			Tasks: []PipelineTask{{
+				Name: "deploy", TaskRef: &TaskRef{Name: "deploy"},
+				WhenExpressions: WhenExpressions{{
+					Input: "$(params.revision[0])", Operator: selection.In, Values: []string{"main"},
+				}},
+			}},
+		},
+		wantErr: `string param "revision" is referenced as an array in "$(params.revision[0])": tasks[0].when[0]`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.ps.ValidatePropagatedParamTypes(provided)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("PipelineSpec.ValidatePropagatedParamTypes() returned error for valid params: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("PipelineSpec.ValidatePropagatedParamTypes() did not return error for mistyped params")
+			}
+			if d := cmp.Diff(tt.wantErr, err.Error()); d != "" {
+				t.Errorf("PipelineSpec.ValidatePropagatedParamTypes() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestValidateParamResults_Success(t *testing.T) {
 	desc := "valid pipeline task referencing task result along with parameter variable"
 	tasks := []PipelineTask{{
@@ -3219,6 +3825,23 @@ func Test_validateMatrix(t *testing.T) {
 			}},
 		}},
 		wantErrs: apis.ErrMultipleOneOf("[0].matrix[foobar]", "[0].params[foobar]"),
+	}, {
+		name: "only some parameters in both matrix and params",
+		tasks: PipelineTaskList{{
+			Name:    "a-task",
+			TaskRef: &TaskRef{Name: "a-task"},
+			Matrix: []Param{{
+				Name: "foo", Value: ArrayOrString{Type: ParamTypeArray, ArrayVal: []string{"foo", "bar"}},
+			}, {
+				Name: "bar", Value: ArrayOrString{Type: ParamTypeArray, ArrayVal: []string{"bar", "foo"}},
+			}},
+			Params: []Param{{
+				Name: "foo", Value: ArrayOrString{Type: ParamTypeString, StringVal: "foo"},
+			}, {
+				Name: "baz", Value: ArrayOrString{Type: ParamTypeString, StringVal: "baz"},
+			}},
+		}},
+		wantErrs: apis.ErrMultipleOneOf("[0].matrix[foo]", "[0].params[foo]"),
 	}, {
 		name: "parameters unique in matrix and params",
 		tasks: PipelineTaskList{{
@@ -239,6 +239,26 @@ type TimeoutFields struct {
 	Finally *metav1.Duration `json:"finally,omitempty"`
 }
 
+// Normalize defaults an unset pipeline timeout to defaultTimeout and, when only one of the tasks and finally
+// timeouts is set, sets the other to what's left of the pipeline timeout, as ResolveTimeouts does. The other
+// one is left unset when nothing is split out of the pipeline timeout, see remainingPhaseTimeout, since the
+// phase then shares the whole pipeline timeout.
+func (t *TimeoutFields) Normalize(defaultTimeout time.Duration) {
+	if t.Pipeline == nil {
+		t.Pipeline = &metav1.Duration{Duration: defaultTimeout}
+	}
+	switch {
+	case t.Finally == nil:
+		if finally, split := remainingPhaseTimeout(t.Pipeline.Duration, t.Tasks); split {
+			t.Finally = &metav1.Duration{Duration: finally}
+		}
+	case t.Tasks == nil:
+		if tasks, split := remainingPhaseTimeout(t.Pipeline.Duration, t.Finally); split {
+			t.Tasks = &metav1.Duration{Duration: tasks}
+		}
+	}
+}
+
 // PipelineRunSpecStatus defines the pipelinerun spec status the user can provide
 type PipelineRunSpecStatus string
 
@@ -546,3 +566,69 @@ func (pr *PipelineRun) GetTaskRunSpec(pipelineTaskName string) PipelineTaskRunSp
 	}
 	return s
 }
+
+// TaskRunSpecFor returns the TaskRunSpec that overrides the run of the given PipelineTask, or nil
+// if the PipelineRunSpec has none for it. If there are several, the first one is returned.
+func (prs *PipelineRunSpec) TaskRunSpecFor(pipelineTaskName string) *PipelineTaskRunSpec {
+	for i := range prs.TaskRunSpecs {
+		if prs.TaskRunSpecs[i].PipelineTaskName == pipelineTaskName {
+			return &prs.TaskRunSpecs[i]
+		}
+	}
+	return nil
+}
+
+// ResolveTimeouts returns the timeouts of the whole PipelineRun and of its tasks and finally phases. Each
+// timeout set in the PipelineRunSpec, including the deprecated Timeout, takes precedence over the one set
+// in defaults. A phase without a timeout gets what's left of the pipeline timeout once the other phase's
+// timeout is taken out of it, or the whole pipeline timeout if neither is set or nothing is left over. A zero
+// timeout is no timeout.
+// An error is returned if the tasks and finally timeouts don't fit in the pipeline timeout.
+func (prs *PipelineRunSpec) ResolveTimeouts(defaults TimeoutFields) (pipeline, tasks, finally time.Duration, err error) {
+	resolved := defaults.DeepCopy()
+	if prs.Timeout != nil {
+		resolved.Pipeline = prs.Timeout
+	}
+	if t := prs.Timeouts; t != nil {
+		if t.Pipeline != nil {
+			resolved.Pipeline = t.Pipeline
+		}
+		if t.Tasks != nil {
+			resolved.Tasks = t.Tasks
+		}
+		if t.Finally != nil {
+			resolved.Finally = t.Finally
+		}
+	}
+
+	if resolved.Pipeline != nil {
+		pipeline = resolved.Pipeline.Duration
+		resolvedSpec := &PipelineRunSpec{Timeouts: resolved}
+		if errs := resolvedSpec.validatePipelineTimeout(pipeline, "should be <= pipeline duration"); errs != nil {
+			return 0, 0, 0, errs
+		}
+	}
+	if resolved.Tasks != nil {
+		tasks = resolved.Tasks.Duration
+	} else {
+		tasks, _ = remainingPhaseTimeout(pipeline, resolved.Finally)
+	}
+	if resolved.Finally != nil {
+		finally = resolved.Finally.Duration
+	} else {
+		finally, _ = remainingPhaseTimeout(pipeline, resolved.Tasks)
+	}
+	return pipeline, tasks, finally, nil
+}
+
+// remainingPhaseTimeout returns the timeout of the tasks or finally phase when it doesn't set one, given the
+// pipeline timeout and the other phase's timeout: what's left of the pipeline timeout once the other phase's
+// timeout is taken out of it. Nothing is split out, and split is false, if the pipeline has no timeout, the
+// other phase doesn't set one or it leaves nothing over, since a zero timeout would be no timeout. The phase
+// then gets the whole pipeline timeout, which bounds it anyway.
+func remainingPhaseTimeout(pipeline time.Duration, other *metav1.Duration) (timeout time.Duration, split bool) {
+	if pipeline == apisconfig.NoTimeoutDuration || other == nil || other.Duration >= pipeline {
+		return pipeline, false
+	}
+	return pipeline - other.Duration, true
+}
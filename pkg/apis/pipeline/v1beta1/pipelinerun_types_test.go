@@ -472,3 +472,228 @@ func TestPipelineRunGetPodSpec(t *testing.T) {
 		}
 	}
 }
+
+func TestPipelineRunSpec_TaskRunSpecFor(t *testing.T) {
+	prs := v1beta1.PipelineRunSpec{
+		TaskRunSpecs: []v1beta1.PipelineTaskRunSpec{{
+			PipelineTaskName:       "build",
+			TaskServiceAccountName: "builder",
+		}, {
+			PipelineTaskName:       "deploy",
+			TaskServiceAccountName: "deployer",
+		}},
+	}
+
+	got := prs.TaskRunSpecFor("deploy")
+	if d := cmp.Diff(&prs.TaskRunSpecs[1], got); d != "" {
+		t.Errorf("TaskRunSpecFor(%q) %s", "deploy", diff.PrintWantGot(d))
+	}
+	if got := prs.TaskRunSpecFor("test"); got != nil {
+		t.Errorf("TaskRunSpecFor(%q) = %v, want nil", "test", got)
+	}
+}
+
+func TestPipelineRunSpec_ResolveTimeouts(t *testing.T) {
+	hour := &metav1.Duration{Duration: time.Hour}
+	defaults := v1beta1.TimeoutFields{Pipeline: hour}
+	tests := []struct {
+		name         string
+		spec         v1beta1.PipelineRunSpec
+		wantPipeline time.Duration
+		wantTasks    time.Duration
+		wantFinally  time.Duration
+	}{{
+		name:         "nothing set uses the defaults",
+		wantPipeline: time.Hour,
+		wantTasks:    time.Hour,
+		wantFinally:  time.Hour,
+	}, {
+		name:         "only pipeline set",
+		spec:         v1beta1.PipelineRunSpec{Timeouts: &v1beta1.TimeoutFields{Pipeline: &metav1.Duration{Duration: 2 * time.Hour}}},
+		wantPipeline: 2 * time.Hour,
+		wantTasks:    2 * time.Hour,
+		wantFinally:  2 * time.Hour,
+	}, {
+		name:         "deprecated timeout set",
+		spec:         v1beta1.PipelineRunSpec{Timeout: &metav1.Duration{Duration: 30 * time.Minute}},
+		wantPipeline: 30 * time.Minute,
+		wantTasks:    30 * time.Minute,
+		wantFinally:  30 * time.Minute,
+	}, {
+		name: "all set",
+		spec: v1beta1.PipelineRunSpec{Timeouts: &v1beta1.TimeoutFields{
+			Pipeline: &metav1.Duration{Duration: 2 * time.Hour},
+			Tasks:    &metav1.Duration{Duration: 90 * time.Minute},
+			Finally:  &metav1.Duration{Duration: 30 * time.Minute},
+		}},
+		wantPipeline: 2 * time.Hour,
+		wantTasks:    90 * time.Minute,
+		wantFinally:  30 * time.Minute,
+	}, {
+		name: "tasks set, finally gets the rest",
+		spec: v1beta1.PipelineRunSpec{Timeouts: &v1beta1.TimeoutFields{
+			Tasks: &metav1.Duration{Duration: 40 * time.Minute},
+		}},
+		wantPipeline: time.Hour,
+		wantTasks:    40 * time.Minute,
+		wantFinally:  20 * time.Minute,
+	}, {
+		name: "finally set, tasks get the rest",
+		spec: v1beta1.PipelineRunSpec{Timeouts: &v1beta1.TimeoutFields{
+			Finally: &metav1.Duration{Duration: 15 * time.Minute},
+		}},
+		wantPipeline: time.Hour,
+		wantTasks:    45 * time.Minute,
+		wantFinally:  15 * time.Minute,
+	}, {
+		name: "tasks take the whole pipeline timeout",
+		spec: v1beta1.PipelineRunSpec{Timeouts: &v1beta1.TimeoutFields{
+			Tasks: &metav1.Duration{Duration: time.Hour},
+		}},
+		wantPipeline: time.Hour,
+		wantTasks:    time.Hour,
+		wantFinally:  time.Hour,
+	}, {
+		name: "no pipeline timeout",
+		spec: v1beta1.PipelineRunSpec{Timeouts: &v1beta1.TimeoutFields{
+			Pipeline: &metav1.Duration{},
+			Tasks:    &metav1.Duration{},
+		}},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pipeline, tasks, finally, err := tt.spec.ResolveTimeouts(defaults)
+			if err != nil {
+				t.Fatalf("PipelineRunSpec.ResolveTimeouts() returned unexpected error: %v", err)
+			}
+			if pipeline != tt.wantPipeline || tasks != tt.wantTasks || finally != tt.wantFinally {
+				t.Errorf("PipelineRunSpec.ResolveTimeouts() = %s, %s, %s, want %s, %s, %s", pipeline, tasks, finally, tt.wantPipeline, tt.wantTasks, tt.wantFinally)
+			}
+		})
+	}
+}
+
+func TestPipelineRunSpec_ResolveTimeouts_Error(t *testing.T) {
+	spec := v1beta1.PipelineRunSpec{Timeouts: &v1beta1.TimeoutFields{
+		Pipeline: &metav1.Duration{Duration: time.Hour},
+		Tasks:    &metav1.Duration{Duration: 45 * time.Minute},
+		Finally:  &metav1.Duration{Duration: 30 * time.Minute},
+	}}
+	_, _, _, err := spec.ResolveTimeouts(v1beta1.TimeoutFields{})
+	if err == nil {
+		t.Fatal("PipelineRunSpec.ResolveTimeouts() did not return error for tasks and finally timeouts exceeding the pipeline timeout")
+	}
+	want := "invalid value: 45m0s + 30m0s should be <= pipeline duration: timeouts.finally, timeouts.tasks"
+	if d := cmp.Diff(want, err.Error()); d != "" {
+		t.Errorf("PipelineRunSpec.ResolveTimeouts() %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestTimeoutFields_Normalize(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		timeouts v1beta1.TimeoutFields
+		want     v1beta1.TimeoutFields
+	}{{
+		name:     "all unset",
+		timeouts: v1beta1.TimeoutFields{},
+		want: v1beta1.TimeoutFields{
+			Pipeline: &metav1.Duration{Duration: time.Hour},
+		},
+	}, {
+		name: "tasks set",
+		timeouts: v1beta1.TimeoutFields{
+			Pipeline: &metav1.Duration{Duration: 2 * time.Hour},
+			Tasks:    &metav1.Duration{Duration: 90 * time.Minute},
+		},
+		want: v1beta1.TimeoutFields{
+			Pipeline: &metav1.Duration{Duration: 2 * time.Hour},
+			Tasks:    &metav1.Duration{Duration: 90 * time.Minute},
+			Finally:  &metav1.Duration{Duration: 30 * time.Minute},
+		},
+	}, {
+		name: "finally set with default pipeline timeout",
+		timeouts: v1beta1.TimeoutFields{
+			Finally: &metav1.Duration{Duration: 10 * time.Minute},
+		},
+		want: v1beta1.TimeoutFields{
+			Pipeline: &metav1.Duration{Duration: time.Hour},
+			Tasks:    &metav1.Duration{Duration: 50 * time.Minute},
+			Finally:  &metav1.Duration{Duration: 10 * time.Minute},
+		},
+	}, {
+		name: "tasks take the whole pipeline timeout",
+		timeouts: v1beta1.TimeoutFields{
+			Tasks: &metav1.Duration{Duration: time.Hour},
+		},
+		want: v1beta1.TimeoutFields{
+			Pipeline: &metav1.Duration{Duration: time.Hour},
+			Tasks:    &metav1.Duration{Duration: time.Hour},
+		},
+	}, {
+		name: "no pipeline timeout",
+		timeouts: v1beta1.TimeoutFields{
+			Pipeline: &metav1.Duration{Duration: 0},
+			Tasks:    &metav1.Duration{Duration: 0},
+		},
+		want: v1beta1.TimeoutFields{
+			Pipeline: &metav1.Duration{Duration: 0},
+			Tasks:    &metav1.Duration{Duration: 0},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.timeouts.Normalize(time.Hour)
+			if d := cmp.Diff(tc.want, tc.timeouts); d != "" {
+				t.Errorf("TimeoutFields.Normalize() %s", diff.PrintWantGot(d))
+			}
+			if err := tc.timeouts.Validate(); err != nil {
+				t.Errorf("TimeoutFields.Validate() returned error for normalized timeouts: %v", err)
+			}
+		})
+	}
+}
+
+func TestTimeoutFields_Validate(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		timeouts v1beta1.TimeoutFields
+		wantErr  string
+	}{{
+		name: "tasks and finally fit in pipeline",
+		timeouts: v1beta1.TimeoutFields{
+			Pipeline: &metav1.Duration{Duration: time.Hour},
+			Tasks:    &metav1.Duration{Duration: 45 * time.Minute},
+			Finally:  &metav1.Duration{Duration: 15 * time.Minute},
+		},
+	}, {
+		name: "tasks and finally exceed pipeline",
+		timeouts: v1beta1.TimeoutFields{
+			Pipeline: &metav1.Duration{Duration: time.Hour},
+			Tasks:    &metav1.Duration{Duration: 45 * time.Minute},
+			Finally:  &metav1.Duration{Duration: 30 * time.Minute},
+		},
+		wantErr: "invalid value: 45m0s + 30m0s should be <= pipeline duration: timeouts.finally, timeouts.tasks",
+	}, {
+		name: "negative finally",
+		timeouts: v1beta1.TimeoutFields{
+			Finally: &metav1.Duration{Duration: -time.Minute},
+		},
+		wantErr: "invalid value: -1m0s should be >= 0: timeouts.finally",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.timeouts.Validate()
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Errorf("TimeoutFields.Validate() returned error for valid timeouts: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("TimeoutFields.Validate() did not return error for invalid timeouts")
+			}
+			if d := cmp.Diff(tc.wantErr, err.Error()); d != "" {
+				t.Errorf("TimeoutFields.Validate() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/validate"
 	"github.com/tektoncd/pipeline/pkg/apis/version"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"knative.dev/pkg/apis"
 )
 
@@ -115,6 +116,17 @@ func (ps *PipelineRunSpec) Validate(ctx context.Context) (errs *apis.FieldError)
 	return errs
 }
 
+// ValidateTaskRunSpecs checks that the TaskRunSpecs of the PipelineRunSpec only override the runs of
+// PipelineTasks that are known, e.g. the Tasks and Finally Tasks of the Pipeline being run.
+func (ps *PipelineRunSpec) ValidateTaskRunSpecs(known sets.String) error {
+	for _, trs := range ps.TaskRunSpecs {
+		if !known.Has(trs.PipelineTaskName) {
+			return fmt.Errorf("PipelineRun's taskrunSpecs defined wrong taskName: %q, does not exist in Pipeline", trs.PipelineTaskName)
+		}
+	}
+	return nil
+}
+
 func validateSpecStatus(status PipelineRunSpecStatus) *apis.FieldError {
 	switch status {
 	case "":
@@ -135,6 +147,23 @@ func validateSpecStatus(status PipelineRunSpecStatus) *apis.FieldError {
 
 }
 
+// Validate checks that none of the timeouts is negative and that the tasks and finally timeouts, on their own
+// and together, fit in the pipeline timeout. Without a pipeline timeout there's nothing to fit them in, so
+// Normalize should be called first to default it.
+func (t *TimeoutFields) Validate() error {
+	var errs *apis.FieldError
+	errs = errs.Also(validateTimeoutDuration("tasks", t.Tasks))
+	errs = errs.Also(validateTimeoutDuration("finally", t.Finally))
+	errs = errs.Also(validateTimeoutDuration("pipeline", t.Pipeline))
+	if t.Pipeline != nil {
+		errs = errs.Also((&PipelineRunSpec{Timeouts: t}).validatePipelineTimeout(t.Pipeline.Duration, "should be <= pipeline duration"))
+	}
+	if errs == nil {
+		return nil
+	}
+	return errs
+}
+
 func validateTimeoutDuration(field string, d *metav1.Duration) (errs *apis.FieldError) {
 	if d != nil && d.Duration < 0 {
 		fieldPath := fmt.Sprintf("timeouts.%s", field)
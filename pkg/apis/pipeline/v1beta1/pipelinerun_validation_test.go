@@ -28,6 +28,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	corev1resources "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"knative.dev/pkg/apis"
 )
 
@@ -358,8 +359,10 @@ func TestPipelineRunSpec_Invalidate(t *testing.T) {
 			Message: "expected exactly one, got neither",
 			Paths: []string{
 				"workspaces[0].configmap",
+				"workspaces[0].csi",
 				"workspaces[0].emptydir",
 				"workspaces[0].persistentvolumeclaim",
+				"workspaces[0].projected",
 				"workspaces[0].secret",
 				"workspaces[0].volumeclaimtemplate",
 			},
@@ -840,3 +843,50 @@ func TestPipelineRunWithTimeout_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestPipelineRunSpec_ValidateTaskRunSpecs(t *testing.T) {
+	known := sets.NewString("build", "deploy")
+	tests := []struct {
+		name    string
+		spec    v1beta1.PipelineRunSpec
+		wantErr string
+	}{{
+		name: "no taskRunSpecs",
+	}, {
+		name: "taskRunSpecs for known tasks",
+		spec: v1beta1.PipelineRunSpec{
+			TaskRunSpecs: []v1beta1.PipelineTaskRunSpec{{
+				PipelineTaskName: "build", TaskServiceAccountName: "builder",
+			}, {
+				PipelineTaskName: "deploy", TaskServiceAccountName: "deployer",
+			}},
+		},
+	}, {
+		name: "taskRunSpec for an unknown task",
+		spec: v1beta1.PipelineRunSpec{
+			TaskRunSpecs: []v1beta1.PipelineTaskRunSpec{{
+				PipelineTaskName: "build", TaskServiceAccountName: "builder",
+			}, {
+				PipelineTaskName: "test", TaskServiceAccountName: "tester",
+			}},
+		},
+		wantErr: `PipelineRun's taskrunSpecs defined wrong taskName: "test", does not exist in Pipeline`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.spec.ValidateTaskRunSpecs(known)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateTaskRunSpecs() returned unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("ValidateTaskRunSpecs() did not return an error")
+			}
+			if d := cmp.Diff(tt.wantErr, err.Error()); d != "" {
+				t.Errorf("ValidateTaskRunSpecs() error %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -83,6 +83,79 @@ func MergePodTemplateWithDefault(tpl, defaultTpl *PodTemplate) *PodTemplate {
 	}
 }
 
+// MergePodTemplate merges override into a copy of base, typically the default pod template.
+// Unlike MergePodTemplateWithDefault, lists and maps set on both templates are combined rather than
+// replaced: NodeSelector entries and Volumes of the same name are taken from override, while
+// Tolerations, ImagePullSecrets and HostAliases of override are appended to those of base. Any
+// other field set in override wins. Neither template is modified.
+func MergePodTemplate(base, override *PodTemplate) *PodTemplate {
+	if base == nil {
+		return override.DeepCopy()
+	}
+	merged := base.DeepCopy()
+	if override == nil {
+		return merged
+	}
+	o := override.DeepCopy()
+
+	if len(o.NodeSelector) > 0 {
+		if merged.NodeSelector == nil {
+			merged.NodeSelector = make(map[string]string, len(o.NodeSelector))
+		}
+		for k, v := range o.NodeSelector {
+			merged.NodeSelector[k] = v
+		}
+	}
+	merged.Tolerations = append(merged.Tolerations, o.Tolerations...)
+	merged.ImagePullSecrets = append(merged.ImagePullSecrets, o.ImagePullSecrets...)
+	merged.HostAliases = append(merged.HostAliases, o.HostAliases...)
+	for _, v := range o.Volumes {
+		replaced := false
+		for i := range merged.Volumes {
+			if merged.Volumes[i].Name == v.Name {
+				merged.Volumes[i] = v
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			merged.Volumes = append(merged.Volumes, v)
+		}
+	}
+
+	if o.Affinity != nil {
+		merged.Affinity = o.Affinity
+	}
+	if o.SecurityContext != nil {
+		merged.SecurityContext = o.SecurityContext
+	}
+	if o.RuntimeClassName != nil {
+		merged.RuntimeClassName = o.RuntimeClassName
+	}
+	if o.AutomountServiceAccountToken != nil {
+		merged.AutomountServiceAccountToken = o.AutomountServiceAccountToken
+	}
+	if o.DNSPolicy != nil {
+		merged.DNSPolicy = o.DNSPolicy
+	}
+	if o.DNSConfig != nil {
+		merged.DNSConfig = o.DNSConfig
+	}
+	if o.EnableServiceLinks != nil {
+		merged.EnableServiceLinks = o.EnableServiceLinks
+	}
+	if o.PriorityClassName != nil {
+		merged.PriorityClassName = o.PriorityClassName
+	}
+	if o.SchedulerName != "" {
+		merged.SchedulerName = o.SchedulerName
+	}
+	if o.HostNetwork {
+		merged.HostNetwork = true
+	}
+	return merged
+}
+
 // AAPodTemplate holds pod specific configuration for the affinity-assistant
 type AAPodTemplate = pod.AffinityAssistantTemplate
 
@@ -0,0 +1,117 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestMergePodTemplate(t *testing.T) {
+	schedulerName := "default-scheduler"
+	for _, tc := range []struct {
+		name     string
+		base     *v1beta1.PodTemplate
+		override *v1beta1.PodTemplate
+		want     *v1beta1.PodTemplate
+	}{{
+		name: "both nil",
+		want: nil,
+	}, {
+		name: "no base",
+		override: &v1beta1.PodTemplate{
+			NodeSelector: map[string]string{"disktype": "ssd"},
+		},
+		want: &v1beta1.PodTemplate{
+			NodeSelector: map[string]string{"disktype": "ssd"},
+		},
+	}, {
+		name: "no override",
+		base: &v1beta1.PodTemplate{
+			SchedulerName: schedulerName,
+		},
+		want: &v1beta1.PodTemplate{
+			SchedulerName: schedulerName,
+		},
+	}, {
+		name: "nodeSelector override",
+		base: &v1beta1.PodTemplate{
+			NodeSelector:  map[string]string{"disktype": "hdd", "zone": "us-east1"},
+			SchedulerName: schedulerName,
+		},
+		override: &v1beta1.PodTemplate{
+			NodeSelector: map[string]string{"disktype": "ssd"},
+		},
+		want: &v1beta1.PodTemplate{
+			NodeSelector:  map[string]string{"disktype": "ssd", "zone": "us-east1"},
+			SchedulerName: schedulerName,
+		},
+	}, {
+		name: "tolerations concatenation",
+		base: &v1beta1.PodTemplate{
+			Tolerations: []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "ci", Effect: corev1.TaintEffectNoSchedule}},
+		},
+		override: &v1beta1.PodTemplate{
+			Tolerations: []corev1.Toleration{{Key: "gpu", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoExecute}},
+		},
+		want: &v1beta1.PodTemplate{
+			Tolerations: []corev1.Toleration{
+				{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "ci", Effect: corev1.TaintEffectNoSchedule},
+				{Key: "gpu", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoExecute},
+			},
+		},
+	}, {
+		name: "volumes merged by name",
+		base: &v1beta1.PodTemplate{
+			Volumes: []corev1.Volume{
+				{Name: "cache", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+				{Name: "config", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{}}},
+			},
+		},
+		override: &v1beta1.PodTemplate{
+			Volumes: []corev1.Volume{
+				{Name: "cache", VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/cache"}}},
+			},
+		},
+		want: &v1beta1.PodTemplate{
+			Volumes: []corev1.Volume{
+				{Name: "cache", VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/cache"}}},
+				{Name: "config", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{}}},
+			},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := v1beta1.MergePodTemplate(tc.base, tc.override)
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("MergePodTemplate() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestMergePodTemplate_DoesNotModifyInputs(t *testing.T) {
+	base := &v1beta1.PodTemplate{NodeSelector: map[string]string{"zone": "us-east1"}}
+	override := &v1beta1.PodTemplate{NodeSelector: map[string]string{"disktype": "ssd"}}
+	v1beta1.MergePodTemplate(base, override)
+	if d := cmp.Diff(map[string]string{"zone": "us-east1"}, base.NodeSelector); d != "" {
+		t.Errorf("MergePodTemplate() modified the base template %s", diff.PrintWantGot(d))
+	}
+}
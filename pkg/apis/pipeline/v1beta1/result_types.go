@@ -34,6 +34,20 @@ type TaskResult struct {
 	Description string `json:"description,omitempty"`
 }
 
+// StepResult used to describe the results of a step
+type StepResult struct {
+	// Name the given name
+	Name string `json:"name"`
+
+	// Type is the user-specified type of the result.
+	// +optional
+	Type ResultsType `json:"type,omitempty"`
+
+	// Description is a human-readable description of the result
+	// +optional
+	Description string `json:"description,omitempty"`
+}
+
 // TaskRunResult used to describe the results of a task
 type TaskRunResult struct {
 	// Name the given name
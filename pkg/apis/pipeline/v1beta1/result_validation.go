@@ -16,20 +16,34 @@ package v1beta1
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/version"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"knative.dev/pkg/apis"
 )
 
+// ReservedResultNamePrefixes are the name prefixes Tekton keeps for the results it writes itself.
+// Task and Step results may not use them.
+var ReservedResultNamePrefixes = []string{"tekton-internal-"}
+
 // Validate implements apis.Validatable
 func (tr TaskResult) Validate(ctx context.Context) (errs *apis.FieldError) {
 	if !resultNameFormatRegex.MatchString(tr.Name) {
 		return apis.ErrInvalidKeyName(tr.Name, "name", fmt.Sprintf("Name must consist of alphanumeric characters, '-', '_', and must start and end with an alphanumeric character (e.g. 'MyName',  or 'my-name',  or 'my_name', regex used for validation is '%s')", ResultNameFormat))
 	}
+	if err := validateReservedResultName(tr.Name); err != nil {
+		return err
+	}
 	// Array and Object is alpha feature
 	if tr.Type == ResultsTypeArray || tr.Type == ResultsTypeObject {
-		return errs.Also(version.ValidateEnabledAPIFields(ctx, "results type", config.AlphaAPIFields))
+		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "results type", config.AlphaAPIFields))
+		// Object results must declare their keys so that they can be validated
+		if tr.Type == ResultsTypeObject && len(tr.Properties) == 0 {
+			errs = errs.Also(apis.ErrMissingField("properties"))
+		}
+		return errs
 	}
 
 	// Resources created before the result. Type was introduced may not have Type set
@@ -45,3 +59,32 @@ func (tr TaskResult) Validate(ctx context.Context) (errs *apis.FieldError) {
 
 	return nil
 }
+
+// Validate implements apis.Validatable
+func (sr StepResult) Validate(ctx context.Context) *apis.FieldError {
+	if msgs := validation.IsDNS1123Label(sr.Name); len(msgs) > 0 {
+		return apis.ErrInvalidKeyName(sr.Name, "name", msgs...)
+	}
+	if err := validateReservedResultName(sr.Name); err != nil {
+		return err
+	}
+	if sr.Type == "" {
+		return nil
+	}
+	for _, t := range AllResultsTypes {
+		if sr.Type == t {
+			return nil
+		}
+	}
+	return apis.ErrInvalidValue(sr.Type, "type", fmt.Sprintf("type must be one of %v", AllResultsTypes))
+}
+
+// validateReservedResultName rejects result names starting with one of the ReservedResultNamePrefixes.
+func validateReservedResultName(name string) *apis.FieldError {
+	for _, prefix := range ReservedResultNamePrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return apis.ErrInvalidKeyName(name, "name", fmt.Sprintf("the prefix %q is reserved", prefix))
+		}
+	}
+	return nil
+}
@@ -62,8 +62,11 @@ func TestResultsValidate(t *testing.T) {
 		name: "valid result type object",
 		Result: v1beta1.TaskResult{
 			Name:        "MY-RESULT",
-			Type:        "array",
+			Type:        "object",
 			Description: "my great result",
+			Properties: map[string]v1beta1.PropertySpec{
+				"url": {Type: "string"},
+			},
 		},
 
 		apiFields: "alpha",
@@ -130,11 +133,26 @@ func TestResultsValidateError(t *testing.T) {
 			Name:        "MY-RESULT",
 			Type:        "object",
 			Description: "my great result",
+			Properties: map[string]v1beta1.PropertySpec{
+				"url": {Type: "string"},
+			},
 		},
 		apiFields: "stable",
 		expectedError: apis.FieldError{
 			Message: "results type requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"stable\"",
 		},
+	}, {
+		name: "invalid object result type without properties",
+		Result: v1beta1.TaskResult{
+			Name:        "MY-RESULT",
+			Type:        "object",
+			Description: "my great result",
+		},
+		apiFields: "alpha",
+		expectedError: apis.FieldError{
+			Message: "missing field(s)",
+			Paths:   []string{"properties"},
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -153,3 +171,88 @@ func TestResultsValidateError(t *testing.T) {
 		})
 	}
 }
+
+func TestResultsValidateReservedName(t *testing.T) {
+	result := v1beta1.TaskResult{
+		Name: "tekton-internal-digest",
+	}
+	expectedError := apis.FieldError{
+		Message: `invalid key name "tekton-internal-digest"`,
+		Paths:   []string{"name"},
+		Details: `the prefix "tekton-internal-" is reserved`,
+	}
+	err := result.Validate(context.Background())
+	if err == nil {
+		t.Fatalf("Expected an error, got nothing for %v", result)
+	}
+	if d := cmp.Diff(expectedError.Error(), err.Error()); d != "" {
+		t.Errorf("TaskResult.Validate() errors diff %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestStepResultValidate(t *testing.T) {
+	tests := []struct {
+		name          string
+		Result        v1beta1.StepResult
+		expectedError *apis.FieldError
+	}{{
+		name: "valid step result",
+		Result: v1beta1.StepResult{
+			Name: "my-result",
+			Type: v1beta1.ResultsTypeString,
+		},
+	}, {
+		name: "valid step result without type",
+		Result: v1beta1.StepResult{
+			Name: "digest",
+		},
+	}, {
+		name: "uppercase name",
+		Result: v1beta1.StepResult{
+			Name: "MY-RESULT",
+		},
+		expectedError: &apis.FieldError{
+			Message: `invalid key name "MY-RESULT"`,
+			Paths:   []string{"name"},
+			Details: "a lowercase RFC 1123 label must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character (e.g. 'my-name',  or '123-abc', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?')",
+		},
+	}, {
+		name: "reserved prefix",
+		Result: v1beta1.StepResult{
+			Name: "tekton-internal-exit-code",
+		},
+		expectedError: &apis.FieldError{
+			Message: `invalid key name "tekton-internal-exit-code"`,
+			Paths:   []string{"name"},
+			Details: `the prefix "tekton-internal-" is reserved`,
+		},
+	}, {
+		name: "invalid type",
+		Result: v1beta1.StepResult{
+			Name: "my-result",
+			Type: "wrong",
+		},
+		expectedError: &apis.FieldError{
+			Message: `invalid value: wrong`,
+			Paths:   []string{"type"},
+			Details: "type must be one of [string array object]",
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.Result.Validate(context.Background())
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Errorf("StepResult.Validate() = %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Expected an error, got nothing for %v", tt.Result)
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("StepResult.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -21,6 +21,8 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/substitution"
 )
 
 // ResultRef is a type that represents a reference to a task run result
@@ -51,6 +53,11 @@ const (
 	arrayIndexing = `\[([0-9])*\*?\]`
 	// ResultNameFormat Constant used to define the the regex Result.Name should follow
 	ResultNameFormat = `^([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]$`
+	// resultsParseNumber is the value of how many parts we split from result reference. e.g.  tasks.<taskName>.results.<objectResultName>
+	resultsParseNumber = 4
+	// objectElementResultsParseNumber is the value of how many parts we split from
+	// object attribute result reference. e.g.  tasks.<taskName>.results.<objectResultName>.<individualAttribute>
+	objectElementResultsParseNumber = 5
 )
 
 // VariableSubstitutionRegex is a regex to find all result matching substitutions
@@ -206,7 +213,8 @@ func ParseResultName(resultName string) (string, string) {
 // in a PipelineTask and returns a list of any references that are found.
 func PipelineTaskResultRefs(pt *PipelineTask) []*ResultRef {
 	refs := []*ResultRef{}
-	for _, p := range append(pt.Params, pt.Matrix...) {
+	// copy the params so that appending the matrix can't write into the backing array of pt.Params
+	for _, p := range append(append([]Param{}, pt.Params...), pt.Matrix...) {
 		expressions, _ := GetVarSubstitutionExpressionsForParam(p)
 		refs = append(refs, NewResultRefs(expressions)...)
 	}
@@ -218,3 +226,116 @@ func PipelineTaskResultRefs(pt *PipelineTask) []*ResultRef {
 
 	return refs
 }
+
+// ResolvePipelineResults resolves the PipelineResults from the results of the TaskRuns and of the Runs of
+// custom tasks, keyed by pipeline task name, and for Runs then by result name, returning the computed set
+// of PipelineRunResults. References to non-existent results result in a PipelineResult being considered
+// invalid and omitted from the returned slice, and in an error naming the invalid PipelineResults. A nil
+// slice is returned if no results are passed in or all results are invalid.
+func ResolvePipelineResults(results []PipelineResult, taskRunResults map[string][]TaskRunResult, runResults map[string]map[string]string) ([]PipelineRunResult, error) {
+	var pipelineRunResults []PipelineRunResult
+	var invalidPipelineResults []string
+	stringReplacements := map[string]string{}
+	arrayReplacements := map[string][]string{}
+	objectReplacements := map[string]map[string]string{}
+	for _, pipelineResult := range results {
+		variablesInPipelineResult, _ := GetVarSubstitutionExpressionsForPipelineResult(pipelineResult)
+		if len(variablesInPipelineResult) == 0 {
+			continue
+		}
+		validPipelineResult := true
+		for _, variable := range variablesInPipelineResult {
+			if _, isMemoized := stringReplacements[variable]; isMemoized {
+				continue
+			}
+			if _, isMemoized := arrayReplacements[variable]; isMemoized {
+				continue
+			}
+			if _, isMemoized := objectReplacements[variable]; isMemoized {
+				continue
+			}
+			variableParts := strings.Split(variable, ".")
+			if len(variableParts) < resultsParseNumber || variableParts[0] != ResultTaskPart || variableParts[2] != ResultResultPart {
+				validPipelineResult = false
+				invalidPipelineResults = append(invalidPipelineResults, pipelineResult.Name)
+				continue
+			}
+			switch len(variableParts) {
+			// For string result: tasks.<taskName>.results.<objectResultName>
+			// For array result: tasks.<taskName>.results.<objectResultName>[*], tasks.<taskName>.results.<objectResultName>[i]
+			// For object result: tasks.<taskName>.results.<objectResultName>[*],
+			case resultsParseNumber:
+				taskName, resultName := variableParts[1], variableParts[3]
+				resultName, stringIdx := ParseResultName(resultName)
+				if resultValue := taskResultValue(taskName, resultName, taskRunResults); resultValue != nil {
+					switch resultValue.Type {
+					case ParamTypeString:
+						stringReplacements[variable] = resultValue.StringVal
+					case ParamTypeArray:
+						if stringIdx != "*" {
+							intIdx, _ := strconv.Atoi(stringIdx)
+							if intIdx < len(resultValue.ArrayVal) {
+								stringReplacements[variable] = resultValue.ArrayVal[intIdx]
+							} else {
+								invalidPipelineResults = append(invalidPipelineResults, pipelineResult.Name)
+								validPipelineResult = false
+							}
+						} else {
+							arrayReplacements[substitution.StripStarVarSubExpression(variable)] = resultValue.ArrayVal
+						}
+					case ParamTypeObject:
+						objectReplacements[substitution.StripStarVarSubExpression(variable)] = resultValue.ObjectVal
+					}
+				} else if resultValue, ok := runResults[taskName][resultName]; ok {
+					stringReplacements[variable] = resultValue
+				} else {
+					// referred array index out of bound
+					invalidPipelineResults = append(invalidPipelineResults, pipelineResult.Name)
+					validPipelineResult = false
+				}
+			// For object type result: tasks.<taskName>.results.<objectResultName>.<individualAttribute>
+			case objectElementResultsParseNumber:
+				taskName, resultName, objectKey := variableParts[1], variableParts[3], variableParts[4]
+				resultName, _ = ParseResultName(resultName)
+				if resultValue := taskResultValue(taskName, resultName, taskRunResults); resultValue != nil {
+					if _, ok := resultValue.ObjectVal[objectKey]; ok {
+						stringReplacements[variable] = resultValue.ObjectVal[objectKey]
+					} else {
+						// referred object key is not existent
+						invalidPipelineResults = append(invalidPipelineResults, pipelineResult.Name)
+						validPipelineResult = false
+					}
+				}
+			default:
+				invalidPipelineResults = append(invalidPipelineResults, pipelineResult.Name)
+				validPipelineResult = false
+			}
+		}
+		if validPipelineResult {
+			finalValue := pipelineResult.Value
+			finalValue.ApplyReplacements(stringReplacements, arrayReplacements, objectReplacements)
+			pipelineRunResults = append(pipelineRunResults, PipelineRunResult{
+				Name:  pipelineResult.Name,
+				Value: finalValue,
+			})
+		}
+	}
+
+	if len(invalidPipelineResults) > 0 {
+		return pipelineRunResults, fmt.Errorf("invalid pipelineresults %v, the referred results don't exist", invalidPipelineResults)
+	}
+
+	return pipelineRunResults, nil
+}
+
+// taskResultValue returns the result value for a given pipeline task name and result name in a map of TaskRunResults for
+// pipeline task names. It returns nil if either the pipeline task name isn't present in the map, or if there is no
+// result with the result name in the pipeline task name's slice of results.
+func taskResultValue(taskName string, resultName string, taskResults map[string][]TaskRunResult) *ArrayOrString {
+	for _, trResult := range taskResults[taskName] {
+		if trResult.Name == resultName {
+			return &trResult.Value
+		}
+	}
+	return nil
+}
@@ -76,6 +76,7 @@ func (ts *TaskSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 	errs = errs.Also(ValidateVolumes(ts.Volumes).ViaField("volumes"))
 	errs = errs.Also(validateDeclaredWorkspaces(ts.Workspaces, ts.Steps, ts.StepTemplate).ViaField("workspaces"))
 	errs = errs.Also(validateWorkspaceUsages(ctx, ts))
+	errs = errs.Also(ValidateStepTemplate(ts.StepTemplate).ViaField("stepTemplate"))
 	mergedSteps, err := MergeStepsWithStepTemplate(ts.StepTemplate, ts.Steps)
 	if err != nil {
 		errs = errs.Also(&apis.FieldError{
@@ -175,6 +176,18 @@ func validateWorkspaceUsages(ctx context.Context, ts *TaskSpec) (errs *apis.Fiel
 	return errs
 }
 
+// ValidateStepTemplate validates a possibly nil StepTemplate. The template must not
+// set a name, since merging it would overwrite the names of the steps.
+func ValidateStepTemplate(template *StepTemplate) *apis.FieldError {
+	if template == nil {
+		return nil
+	}
+	if template.DeprecatedName != "" {
+		return apis.ErrDisallowedFields("name")
+	}
+	return nil
+}
+
 // ValidateVolumes validates a slice of volumes to make sure there are no dupilcate names
 func ValidateVolumes(volumes []corev1.Volume) (errs *apis.FieldError) {
 	// Task must not have duplicate volume names.
@@ -280,11 +293,32 @@ func ValidateParameterTypes(ctx context.Context, params []ParamSpec) (errs *apis
 			// when the enable-api-fields feature gate is not "alpha".
 			errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "object type parameter", config.AlphaAPIFields))
 		}
+		if len(p.Enum) != 0 {
+			// Enum is an alpha feature and will fail validation if it's used in a task spec
+			// when the enable-api-fields feature gate is not "alpha".
+			errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "enum", config.AlphaAPIFields))
+		}
 		errs = errs.Also(p.ValidateType())
+		errs = errs.Also(p.validateEnum())
 	}
 	return errs
 }
 
+// validateEnum checks that the Enum of a ParamSpec is only used for a string param and
+// that its default value is one of the allowed values.
+func (p ParamSpec) validateEnum() *apis.FieldError {
+	if len(p.Enum) == 0 {
+		return nil
+	}
+	if p.Type != ParamTypeString {
+		return apis.ErrGeneric(fmt.Sprintf("enum is only allowed for parameters of type string but %q is of type %q", p.Name, p.Type), fmt.Sprintf("%s.enum", p.Name))
+	}
+	if p.Default != nil && !p.AllowsValue(p.Default.StringVal) {
+		return apis.ErrInvalidValue(p.Default.StringVal, fmt.Sprintf("%s.default", p.Name), fmt.Sprintf("allowed values are %s", strings.Join(p.Enum, ", ")))
+	}
+	return nil
+}
+
 // ValidateType checks that the type of a ParamSpec is allowed and its default value matches that type
 func (p ParamSpec) ValidateType() *apis.FieldError {
 	// Ensure param has a valid type.
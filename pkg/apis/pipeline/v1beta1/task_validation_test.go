@@ -359,6 +359,9 @@ func TestTaskSpecValidate(t *testing.T) {
 				Name:        "MY-RESULT",
 				Type:        v1beta1.ResultsTypeObject,
 				Description: "my great result",
+				Properties: map[string]v1beta1.PropertySpec{
+					"url": {Type: "string"},
+				},
 			}},
 		},
 	}, {
@@ -1195,6 +1198,19 @@ func TestTaskSpecValidateError(t *testing.T) {
 			Message: "workspace mount path \"/workspace/some-workspace\" must be unique",
 			Paths:   []string{"workspaces[0].mountpath"},
 		},
+	}, {
+		name: "stepTemplate sets name",
+		fields: fields{
+			StepTemplate: &v1beta1.StepTemplate{
+				DeprecatedName: "template-name",
+				Image:          "some-image",
+			},
+			Steps: validSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: "must not set the field(s)",
+			Paths:   []string{"stepTemplate.name"},
+		},
 	}, {
 		name: "workspace mount path already in stepTemplate",
 		fields: fields{
@@ -1505,6 +1521,17 @@ func TestIncompatibleAPIVersions(t *testing.T) {
 				}},
 			}},
 		},
+	}, {
+		name:            "param enum requires alpha",
+		requiredVersion: "alpha",
+		spec: v1beta1.TaskSpec{
+			Params: []v1beta1.ParamSpec{{
+				Name: "mode",
+				Type: v1beta1.ParamTypeString,
+				Enum: []string{"fast", "slow"},
+			}},
+			Steps: validSteps,
+		},
 	}, {
 		name:            "windows script support requires alpha",
 		requiredVersion: "alpha",
@@ -1565,6 +1592,69 @@ func TestIncompatibleAPIVersions(t *testing.T) {
 	}
 }
 
+func TestParamSpecEnum(t *testing.T) {
+	tests := []struct {
+		name          string
+		params        []v1beta1.ParamSpec
+		expectedError *apis.FieldError
+	}{{
+		name: "valid enum and default",
+		params: []v1beta1.ParamSpec{{
+			Name:    "mode",
+			Type:    v1beta1.ParamTypeString,
+			Enum:    []string{"fast", "slow"},
+			Default: v1beta1.NewArrayOrString("slow"),
+		}},
+	}, {
+		name: "default not in enum",
+		params: []v1beta1.ParamSpec{{
+			Name:    "mode",
+			Type:    v1beta1.ParamTypeString,
+			Enum:    []string{"fast", "slow"},
+			Default: v1beta1.NewArrayOrString("medium"),
+		}},
+		expectedError: &apis.FieldError{
+			Message: "invalid value: medium",
+			Paths:   []string{"params.mode.default"},
+			Details: "allowed values are fast, slow",
+		},
+	}, {
+		name: "enum on an array param",
+		params: []v1beta1.ParamSpec{{
+			Name: "modes",
+			Type: v1beta1.ParamTypeArray,
+			Enum: []string{"fast", "slow"},
+		}},
+		expectedError: &apis.FieldError{
+			Message: `enum is only allowed for parameters of type string but "modes" is of type "array"`,
+			Paths:   []string{"params.modes.enum"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1beta1.TaskSpec{
+				Params: tt.params,
+				Steps:  validSteps,
+			}
+			ctx := config.EnableAlphaAPIFields(context.Background())
+			ts.SetDefaults(ctx)
+			err := ts.Validate(ctx)
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Errorf("TaskSpec.Validate() = %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Expected an error, got nothing for %v", ts)
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestSubstitutedContext(t *testing.T) {
 	type fields struct {
 		Params              []v1beta1.ParamSpec
@@ -1647,3 +1737,17 @@ func TestSubstitutedContext(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateStepTemplate(t *testing.T) {
+	if err := v1beta1.ValidateStepTemplate(nil); err != nil {
+		t.Errorf("ValidateStepTemplate(nil) = %v", err)
+	}
+	if err := v1beta1.ValidateStepTemplate(&v1beta1.StepTemplate{Image: "some-image"}); err != nil {
+		t.Errorf("ValidateStepTemplate() = %v", err)
+	}
+	err := v1beta1.ValidateStepTemplate(&v1beta1.StepTemplate{DeprecatedName: "template-name"})
+	want := apis.ErrDisallowedFields("name")
+	if d := cmp.Diff(want.Error(), err.Error()); d != "" {
+		t.Errorf("ValidateStepTemplate() errors diff %s", diff.PrintWantGot(d))
+	}
+}
@@ -36,6 +36,18 @@ type TaskRef struct {
 	ResolverRef `json:",omitempty"`
 }
 
+// ResolverParams returns the params that are passed to the TaskRef's resolver as string Params.
+func (ref *TaskRef) ResolverParams() []Param {
+	if ref == nil || len(ref.Resource) == 0 {
+		return nil
+	}
+	params := make([]Param, 0, len(ref.Resource))
+	for _, p := range ref.Resource {
+		params = append(params, Param{Name: p.Name, Value: *NewArrayOrString(p.Value)})
+	}
+	return params
+}
+
 // Check that Pipeline may be validated and defaulted.
 
 // TaskKind defines the type of Task used by the pipeline.
@@ -49,9 +49,10 @@ func (ref *TaskRef) Validate(ctx context.Context) (errs *apis.FieldError) {
 		if ref.Bundle != "" {
 			errs = errs.Also(apis.ErrMultipleOneOf("bundle", "resource"))
 		}
-		if ref.Resolver == "" {
-			errs = errs.Also(apis.ErrMissingField("resolver"))
-		}
+		// Resource params are only ever passed to a resolver, so they can't be used without one,
+		// whichever resolver it would be. The params that a resolver requires, such as the url of
+		// the git resolver, are checked by that resolver when it resolves the ref.
+		errs = errs.Also(apis.ErrMissingField("resolver"))
 	case ref.Name == "":
 		errs = errs.Also(apis.ErrMissingField("name"))
 	case ref.Bundle != "":
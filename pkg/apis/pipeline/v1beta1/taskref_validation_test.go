@@ -51,6 +51,24 @@ func TestTaskRef_Valid(t *testing.T) {
 			Value: "baz",
 		}}}},
 		wc: config.EnableAlphaAPIFields,
+	}, {
+		name: "alpha feature: git resolver with url",
+		taskRef: &v1beta1.TaskRef{ResolverRef: v1beta1.ResolverRef{Resolver: "git", Resource: []v1beta1.ResolverParam{{
+			Name:  "url",
+			Value: "https://github.com/tektoncd/catalog.git",
+		}, {
+			Name:  "pathInRepo",
+			Value: "task/git-clone/0.6/git-clone.yaml",
+		}}}},
+		wc: config.EnableAlphaAPIFields,
+	}, {
+		// required resolver params are checked by the resolver, not by the TaskRef
+		name: "alpha feature: git resolver without url",
+		taskRef: &v1beta1.TaskRef{ResolverRef: v1beta1.ResolverRef{Resolver: "git", Resource: []v1beta1.ResolverParam{{
+			Name:  "pathInRepo",
+			Value: "task/git-clone/0.6/git-clone.yaml",
+		}}}},
+		wc: config.EnableAlphaAPIFields,
 	}, {
 		name: "valid bundle",
 		taskRef: &v1beta1.TaskRef{
@@ -128,6 +146,18 @@ func TestTaskRef_Invalid(t *testing.T) {
 		},
 		wantErr: apis.ErrMissingField("resolver"),
 		wc:      config.EnableAlphaAPIFields,
+	}, {
+		name: "taskref resource params disallowed without resolver",
+		taskRef: &v1beta1.TaskRef{
+			ResolverRef: v1beta1.ResolverRef{
+				Resource: []v1beta1.ResolverParam{{
+					Name:  "url",
+					Value: "https://github.com/tektoncd/catalog.git",
+				}},
+			},
+		},
+		wantErr: apis.ErrMissingField("resolver"),
+		wc:      config.EnableAlphaAPIFields,
 	}, {
 		name: "taskref resolver disallowed in conjunction with taskref name",
 		taskRef: &v1beta1.TaskRef{
@@ -188,3 +218,22 @@ func TestTaskRef_Invalid(t *testing.T) {
 		})
 	}
 }
+
+func TestTaskRef_ResolverParams(t *testing.T) {
+	ref := &v1beta1.TaskRef{ResolverRef: v1beta1.ResolverRef{Resolver: "git", Resource: []v1beta1.ResolverParam{{
+		Name: "url", Value: "https://github.com/tektoncd/catalog.git",
+	}, {
+		Name: "revision", Value: "main",
+	}}}}
+	want := []v1beta1.Param{{
+		Name: "url", Value: *v1beta1.NewArrayOrString("https://github.com/tektoncd/catalog.git"),
+	}, {
+		Name: "revision", Value: *v1beta1.NewArrayOrString("main"),
+	}}
+	if d := cmp.Diff(want, ref.ResolverParams()); d != "" {
+		t.Errorf("TaskRef.ResolverParams() %s", diff.PrintWantGot(d))
+	}
+	if params := (&v1beta1.TaskRef{Name: "foo"}).ResolverParams(); params != nil {
+		t.Errorf("TaskRef.ResolverParams() = %v for a TaskRef without resolver, want nil", params)
+	}
+}
@@ -18,6 +18,7 @@ package v1beta1
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -307,6 +308,31 @@ type StepState struct {
 	ImageID               string `json:"imageID,omitempty"`
 }
 
+// MatchResults returns the values of the declared results that the step wrote to its
+// termination message, keyed by result name. Results that are not declared are ignored.
+// An empty map is returned when the step has not terminated or its termination message
+// can't be parsed.
+func (ss *StepState) MatchResults(declared []StepResult) map[string]string {
+	values := map[string]string{}
+	if ss.Terminated == nil || ss.Terminated.Message == "" {
+		return values
+	}
+	var results []PipelineResourceResult
+	if err := json.Unmarshal([]byte(ss.Terminated.Message), &results); err != nil {
+		return values
+	}
+	names := map[string]bool{}
+	for _, r := range declared {
+		names[r.Name] = true
+	}
+	for _, r := range results {
+		if r.ResultType == TaskRunResultType && names[r.Key] {
+			values[r.Key] = r.Value
+		}
+	}
+	return values
+}
+
 // SidecarState reports the results of running a sidecar in a Task.
 type SidecarState struct {
 	corev1.ContainerState `json:",inline"`
@@ -440,12 +466,18 @@ func (tr *TaskRun) HasTimedOut(ctx context.Context, c clock.PassiveClock) bool {
 
 // GetTimeout returns the timeout for the TaskRun, or the default if not specified
 func (tr *TaskRun) GetTimeout(ctx context.Context) time.Duration {
+	return tr.Spec.GetTimeout(ctx)
+}
+
+// GetTimeout returns the timeout for the TaskRunSpec, or the default if not specified.
+// An explicit timeout of zero means that there is no timeout and is returned as is.
+func (trs *TaskRunSpec) GetTimeout(ctx context.Context) time.Duration {
 	// Use the platform default is no timeout is set
-	if tr.Spec.Timeout == nil {
+	if trs.Timeout == nil {
 		defaultTimeout := time.Duration(config.FromContextOrDefaults(ctx).Defaults.DefaultTimeoutMinutes)
 		return defaultTimeout * time.Minute
 	}
-	return tr.Spec.Timeout.Duration
+	return trs.Timeout.Duration
 }
 
 // GetNamespacedName returns a k8s namespaced name that identifies this TaskRun
@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/test/diff"
@@ -321,6 +322,53 @@ func TestHasTimedOut(t *testing.T) {
 	}
 }
 
+func TestTaskRunSpec_GetTimeout(t *testing.T) {
+	testCases := []struct {
+		name     string
+		spec     v1beta1.TaskRunSpec
+		defaults *config.Defaults
+		expected time.Duration
+	}{{
+		name:     "nil timeout uses the default",
+		spec:     v1beta1.TaskRunSpec{},
+		expected: config.DefaultTimeoutMinutes * time.Minute,
+	}, {
+		name: "nil timeout uses the configured default",
+		spec: v1beta1.TaskRunSpec{},
+		defaults: &config.Defaults{
+			DefaultTimeoutMinutes: 30,
+		},
+		expected: 30 * time.Minute,
+	}, {
+		name: "explicit timeout",
+		spec: v1beta1.TaskRunSpec{
+			Timeout: &metav1.Duration{Duration: 10 * time.Second},
+		},
+		defaults: &config.Defaults{
+			DefaultTimeoutMinutes: 30,
+		},
+		expected: 10 * time.Second,
+	}, {
+		name: "zero timeout means no timeout",
+		spec: v1beta1.TaskRunSpec{
+			Timeout: &metav1.Duration{Duration: 0},
+		},
+		expected: 0,
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.defaults != nil {
+				ctx = config.ToContext(ctx, &config.Config{Defaults: tc.defaults})
+			}
+			if d := cmp.Diff(tc.expected, tc.spec.GetTimeout(ctx)); d != "" {
+				t.Errorf("TaskRunSpec.GetTimeout() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestInitializeTaskRunConditions(t *testing.T) {
 	tr := &v1beta1.TaskRun{
 		ObjectMeta: metav1.ObjectMeta{
@@ -354,3 +402,67 @@ func TestInitializeTaskRunConditions(t *testing.T) {
 		t.Fatalf("PipelineRun initialize reset the condition reason to %s", newCondition.Reason)
 	}
 }
+
+func TestStepState_MatchResults(t *testing.T) {
+	declared := []v1beta1.StepResult{{
+		Name: "digest",
+	}, {
+		Name: "url",
+	}}
+	tcs := []struct {
+		name      string
+		stepState v1beta1.StepState
+		want      map[string]string
+	}{{
+		name: "step emitting two results",
+		stepState: v1beta1.StepState{
+			ContainerState: corev1.ContainerState{
+				Terminated: &corev1.ContainerStateTerminated{
+					Message: `[{"key":"digest","value":"sha256:1234","type":1},{"key":"url","value":"gcr.io/foo/bar","type":1},{"key":"StartedAt","value":"2022-01-01T00:00:00.000Z","type":3}]`,
+				},
+			},
+		},
+		want: map[string]string{
+			"digest": "sha256:1234",
+			"url":    "gcr.io/foo/bar",
+		},
+	}, {
+		name: "undeclared results are ignored",
+		stepState: v1beta1.StepState{
+			ContainerState: corev1.ContainerState{
+				Terminated: &corev1.ContainerStateTerminated{
+					Message: `[{"key":"digest","value":"sha256:1234","type":1},{"key":"other","value":"foo","type":1}]`,
+				},
+			},
+		},
+		want: map[string]string{
+			"digest": "sha256:1234",
+		},
+	}, {
+		name: "step emitting no results",
+		stepState: v1beta1.StepState{
+			ContainerState: corev1.ContainerState{
+				Terminated: &corev1.ContainerStateTerminated{
+					ExitCode: 0,
+				},
+			},
+		},
+		want: map[string]string{},
+	}, {
+		name: "step still running",
+		stepState: v1beta1.StepState{
+			ContainerState: corev1.ContainerState{
+				Running: &corev1.ContainerStateRunning{},
+			},
+		},
+		want: map[string]string{},
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.stepState.MatchResults(declared)
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("MatchResults() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -102,6 +102,19 @@ func (wes WhenExpressions) AllowsExecution() bool {
 	return true
 }
 
+// AllowsExecutionWith interpolates the given replacements into a copy of the When Expressions and then
+// evaluates them as AllowsExecution does. The When Expressions themselves are left unchanged, which makes it
+// usable by tooling that needs to evaluate them outside of the reconciler.
+func (wes WhenExpressions) AllowsExecutionWith(replacements map[string]string) bool {
+	for _, we := range wes {
+		replaced := we.applyReplacements(replacements, nil)
+		if !replaced.isTrue() {
+			return false
+		}
+	}
+	return true
+}
+
 // ReplaceWhenExpressionsVariables interpolates variables, such as Parameters and Results, in
 // the Input and Values.
 func (wes WhenExpressions) ReplaceWhenExpressionsVariables(replacements map[string]string, arrayReplacements map[string][]string) WhenExpressions {
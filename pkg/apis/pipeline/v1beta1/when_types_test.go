@@ -88,6 +88,70 @@ func TestAllowsExecution(t *testing.T) {
 	}
 }
 
+func TestAllowsExecutionWith(t *testing.T) {
+	replacements := map[string]string{
+		"params.branch":            "main",
+		"tasks.build.results.kind": "release",
+	}
+	tests := []struct {
+		name            string
+		whenExpressions WhenExpressions
+		expected        bool
+	}{{
+		name: "in expression matches",
+		whenExpressions: WhenExpressions{{
+			Input:    "$(params.branch)",
+			Operator: selection.In,
+			Values:   []string{"main", "release-v1"},
+		}},
+		expected: true,
+	}, {
+		name: "notin expression matches",
+		whenExpressions: WhenExpressions{{
+			Input:    "$(tasks.build.results.kind)",
+			Operator: selection.NotIn,
+			Values:   []string{"release"},
+		}},
+		expected: false,
+	}, {
+		name: "multiple expressions - all true",
+		whenExpressions: WhenExpressions{{
+			Input:    "$(params.branch)",
+			Operator: selection.In,
+			Values:   []string{"main"},
+		}, {
+			Input:    "$(tasks.build.results.kind)",
+			Operator: selection.NotIn,
+			Values:   []string{"snapshot"},
+		}},
+		expected: true,
+	}, {
+		name: "multiple expressions - one false",
+		whenExpressions: WhenExpressions{{
+			Input:    "$(params.branch)",
+			Operator: selection.In,
+			Values:   []string{"main"},
+		}, {
+			Input:    "release",
+			Operator: selection.In,
+			Values:   []string{"$(params.branch)"},
+		}},
+		expected: false,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			original := tc.whenExpressions.DeepCopy()
+			got := tc.whenExpressions.AllowsExecutionWith(replacements)
+			if d := cmp.Diff(tc.expected, got); d != "" {
+				t.Errorf("Error evaluating AllowsExecutionWith() for When Expressions in test case %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(original, tc.whenExpressions); d != "" {
+				t.Errorf("AllowsExecutionWith() modified the When Expressions %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestReplaceWhenExpressionsVariables(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -85,6 +85,12 @@ type WorkspaceBinding struct {
 	CSI *corev1.CSIVolumeSource `json:"csi,omitempty"`
 }
 
+// EffectivePath returns the path that the SubPath of b is at when the volume is mounted at mountPath.
+// It's mountPath itself if b has no SubPath.
+func (b *WorkspaceBinding) EffectivePath(mountPath string) string {
+	return filepath.Join(mountPath, b.SubPath)
+}
+
 // WorkspacePipelineDeclaration creates a named slot in a Pipeline that a PipelineRun
 // is expected to populate with a workspace binding.
 // Deprecated: use PipelineWorkspaceDeclaration type instead
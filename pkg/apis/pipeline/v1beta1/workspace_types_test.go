@@ -0,0 +1,49 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1_test
+
+import (
+	"testing"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+)
+
+func TestWorkspaceBinding_EffectivePath(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		subPath string
+		want    string
+	}{{
+		name: "no subPath",
+		want: "/workspace/source",
+	}, {
+		name:    "subPath",
+		subPath: "some/dir",
+		want:    "/workspace/source/some/dir",
+	}, {
+		name:    "subPath with trailing slash",
+		subPath: "some/dir/",
+		want:    "/workspace/source/some/dir",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			b := v1beta1.WorkspaceBinding{Name: "source", SubPath: tc.subPath}
+			if got := b.EffectivePath("/workspace/source"); got != tc.want {
+				t.Errorf("EffectivePath() = %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
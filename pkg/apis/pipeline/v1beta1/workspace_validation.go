@@ -18,6 +18,8 @@ package v1beta1
 
 import (
 	"context"
+	"path/filepath"
+	"strings"
 
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/version"
@@ -33,6 +35,8 @@ var allVolumeSourceFields = []string{
 	"emptydir",
 	"configmap",
 	"secret",
+	"projected",
+	"csi",
 }
 
 // Validate looks at the Volume provided in wb and makes sure that it is valid.
@@ -43,6 +47,10 @@ func (b *WorkspaceBinding) Validate(ctx context.Context) (errs *apis.FieldError)
 		return apis.ErrMissingField(apis.CurrentField)
 	}
 
+	if err := validateSubPath(b.SubPath); err != nil {
+		return err
+	}
+
 	numSources := b.numSources()
 
 	if numSources > 1 {
@@ -94,6 +102,19 @@ func (b *WorkspaceBinding) Validate(ctx context.Context) (errs *apis.FieldError)
 	return nil
 }
 
+// validateSubPath makes sure that subPath is a relative path that stays within the volume.
+func validateSubPath(subPath string) *apis.FieldError {
+	if filepath.IsAbs(subPath) {
+		return apis.ErrInvalidValue(subPath, "subPath", "subPath must be a relative path")
+	}
+	for _, element := range strings.Split(subPath, "/") {
+		if element == ".." {
+			return apis.ErrInvalidValue(subPath, "subPath", "subPath must not contain '..'")
+		}
+	}
+	return nil
+}
+
 // numSources returns the total number of volume sources that this WorkspaceBinding
 // has been configured with.
 func (b *WorkspaceBinding) numSources() int {
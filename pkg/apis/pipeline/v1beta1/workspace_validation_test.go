@@ -58,6 +58,13 @@ func TestWorkspaceBindingValidateValid(t *testing.T) {
 				},
 			},
 		},
+	}, {
+		name: "Valid subPath",
+		binding: &v1beta1.WorkspaceBinding{
+			Name:     "beth",
+			SubPath:  "some/sub..dir",
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
 	}, {
 		name: "Valid emptyDir",
 		binding: &v1beta1.WorkspaceBinding{
@@ -148,6 +155,20 @@ func TestWorkspaceBindingValidateInvalid(t *testing.T) {
 		binding: &v1beta1.WorkspaceBinding{
 			Name: "beth",
 		},
+	}, {
+		name: "Provided subPath with a traversal",
+		binding: &v1beta1.WorkspaceBinding{
+			Name:     "beth",
+			SubPath:  "some/../../dir",
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	}, {
+		name: "Provided absolute subPath",
+		binding: &v1beta1.WorkspaceBinding{
+			Name:     "beth",
+			SubPath:  "/some/dir",
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
 	}, {
 		name: "Provided pvc without claim name",
 		binding: &v1beta1.WorkspaceBinding{
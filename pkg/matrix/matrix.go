@@ -25,3 +25,31 @@ func FanOut(params []v1beta1.Param) Combinations {
 	}
 	return combinations
 }
+
+// FanOutWithInclude produces combinations of Parameters of type String from a slice of Parameters of type Array,
+// and then merges in the explicit combinations from include.
+//
+// An include entry is applied to every combination whose values match all the include Parameters that are also
+// declared in the matrix; its remaining Parameters are added to those combinations, overwriting any value set by an
+// earlier include entry. When no combination matches, the include entry is added as a new combination.
+func FanOutWithInclude(params []v1beta1.Param, include []Include) Combinations {
+	combinations := FanOut(params)
+	matrixParamNames := map[string]bool{}
+	for _, param := range params {
+		matrixParamNames[param.Name] = true
+	}
+	fannedOut := len(combinations)
+	for _, inc := range include {
+		matched := false
+		for _, combination := range combinations[:fannedOut] {
+			if combination.matches(inc.Params, matrixParamNames) {
+				combination.overwrite(inc.Params, matrixParamNames)
+				matched = true
+			}
+		}
+		if !matched {
+			combinations = append(combinations, createIncludeCombination(len(combinations), inc.Params))
+		}
+	}
+	return combinations
+}
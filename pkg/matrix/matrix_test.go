@@ -151,3 +151,107 @@ func Test_FanOut(t *testing.T) {
 		})
 	}
 }
+
+func Test_FanOutWithInclude(t *testing.T) {
+	str := func(name, value string) v1beta1.Param {
+		return v1beta1.Param{Name: name, Value: v1beta1.ArrayOrString{Type: v1beta1.ParamTypeString, StringVal: value}}
+	}
+	tests := []struct {
+		name             string
+		matrix           []v1beta1.Param
+		include          []Include
+		wantCombinations Combinations
+	}{{
+		name:             "empty matrix",
+		wantCombinations: nil,
+	}, {
+		name: "params only",
+		matrix: []v1beta1.Param{{
+			Name:  "platform",
+			Value: v1beta1.ArrayOrString{Type: v1beta1.ParamTypeArray, ArrayVal: []string{"linux", "mac"}},
+		}},
+		wantCombinations: Combinations{{
+			MatrixID: "0",
+			Params:   []v1beta1.Param{str("platform", "linux")},
+		}, {
+			MatrixID: "1",
+			Params:   []v1beta1.Param{str("platform", "mac")},
+		}},
+	}, {
+		name: "include only",
+		include: []Include{{
+			Params: []v1beta1.Param{str("platform", "linux"), str("arch", "amd64")},
+		}, {
+			Params: []v1beta1.Param{str("platform", "mac"), str("arch", "arm64")},
+		}},
+		wantCombinations: Combinations{{
+			MatrixID: "0",
+			Params:   []v1beta1.Param{str("platform", "linux"), str("arch", "amd64")},
+		}, {
+			MatrixID: "1",
+			Params:   []v1beta1.Param{str("platform", "mac"), str("arch", "arm64")},
+		}},
+	}, {
+		name: "params and include overlap",
+		matrix: []v1beta1.Param{{
+			Name:  "platform",
+			Value: v1beta1.ArrayOrString{Type: v1beta1.ParamTypeArray, ArrayVal: []string{"linux", "mac"}},
+		}, {
+			Name:  "browser",
+			Value: v1beta1.ArrayOrString{Type: v1beta1.ParamTypeArray, ArrayVal: []string{"chrome", "safari"}},
+		}},
+		include: []Include{{
+			// no matrix params: added to every combination
+			Params: []v1beta1.Param{str("version", "1")},
+		}, {
+			// matches the linux combinations only, overwriting version
+			Params: []v1beta1.Param{str("platform", "linux"), str("version", "2")},
+		}, {
+			// matches no combination: added as a new combination
+			Params: []v1beta1.Param{str("platform", "windows"), str("browser", "edge")},
+		}},
+		wantCombinations: Combinations{{
+			MatrixID: "0",
+			Params:   []v1beta1.Param{str("platform", "linux"), str("browser", "chrome"), str("version", "2")},
+		}, {
+			MatrixID: "1",
+			Params:   []v1beta1.Param{str("platform", "mac"), str("browser", "chrome"), str("version", "1")},
+		}, {
+			MatrixID: "2",
+			Params:   []v1beta1.Param{str("platform", "linux"), str("browser", "safari"), str("version", "2")},
+		}, {
+			MatrixID: "3",
+			Params:   []v1beta1.Param{str("platform", "mac"), str("browser", "safari"), str("version", "1")},
+		}, {
+			MatrixID: "4",
+			Params:   []v1beta1.Param{str("platform", "windows"), str("browser", "edge")},
+		}},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotCombinations := FanOutWithInclude(tt.matrix, tt.include)
+			if d := cmp.Diff(tt.wantCombinations, gotCombinations); d != "" {
+				t.Errorf("Combinations of Parameters did not match the expected Combinations: %s", d)
+			}
+		})
+	}
+}
+
+func Test_FanOutWithInclude_IncludeOnlyNames(t *testing.T) {
+	str := func(name, value string) v1beta1.Param {
+		return v1beta1.Param{Name: name, Value: v1beta1.ArrayOrString{Type: v1beta1.ParamTypeString, StringVal: value}}
+	}
+	include := []Include{{
+		Params: []v1beta1.Param{str("platform", "linux"), str("arch", "amd64")},
+	}, {
+		Params: []v1beta1.Param{str("platform", "mac"), str("arch", "arm64")},
+	}}
+	var gotNames []string
+	for _, combination := range FanOutWithInclude(nil, include) {
+		gotNames = append(gotNames, combination.Name("pr-a-task"))
+	}
+	wantNames := []string{"pr-a-task-arch-amd64-platform-linux", "pr-a-task-arch-arm64-platform-mac"}
+	if d := cmp.Diff(wantNames, gotNames); d != "" {
+		t.Errorf("Names of the Combinations did not match the expected names: %s", d)
+	}
+}
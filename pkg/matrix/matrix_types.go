@@ -14,11 +14,18 @@
 package matrix
 
 import (
+	"regexp"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"knative.dev/pkg/kmeta"
 )
 
+// invalidNameCharsRegex matches the characters that can't be used in a Kubernetes resource name
+var invalidNameCharsRegex = regexp.MustCompile(`[^a-z0-9-]+`)
+
 // Combinations is a slice of combinations of Parameters from a Matrix.
 type Combinations []*Combination
 
@@ -31,6 +38,12 @@ type Combination struct {
 	Params []v1beta1.Param
 }
 
+// Include is an explicit combination of Parameters to be merged into the combinations from a Matrix.
+type Include struct {
+	// Params is a set of Parameters of type String in this explicit combination.
+	Params []v1beta1.Param
+}
+
 func (combinations Combinations) fanOut(param v1beta1.Param) Combinations {
 	if len(combinations) == 0 {
 		return initializeCombinations(param)
@@ -80,3 +93,72 @@ func (combinations Combinations) ToMap() map[string][]v1beta1.Param {
 	}
 	return m
 }
+
+// Name returns a name for the combination made of prefix followed by the combination's Parameters as
+// name-value pairs, sorted by name so that the name doesn't depend on the order of the Parameters. Characters
+// that aren't allowed in a Kubernetes resource name are replaced with '-'. Names that would be longer than
+// a resource name may be are shortened using a hash, as kmeta.ChildName does.
+func (combination *Combination) Name(prefix string) string {
+	params := append([]v1beta1.Param{}, combination.Params...)
+	sort.Slice(params, func(i, j int) bool {
+		return params[i].Name < params[j].Name
+	})
+	var parts []string
+	for _, p := range params {
+		parts = append(parts, p.Name, p.Value.StringVal)
+	}
+	suffix := invalidNameCharsRegex.ReplaceAllString(strings.ToLower(strings.Join(parts, "-")), "-")
+	return kmeta.ChildName(prefix, "-"+suffix)
+}
+
+// matches returns true if every Parameter in params that is declared in the matrix has the same value in the
+// combination.
+func (combination *Combination) matches(params []v1beta1.Param, matrixParamNames map[string]bool) bool {
+	for _, param := range params {
+		if !matrixParamNames[param.Name] {
+			continue
+		}
+		found := false
+		for _, p := range combination.Params {
+			if p.Name == param.Name {
+				found = p.Value.StringVal == param.Value.StringVal
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// overwrite adds the Parameters in params that are not declared in the matrix to the combination, replacing any
+// existing Parameter with the same name.
+func (combination *Combination) overwrite(params []v1beta1.Param, matrixParamNames map[string]bool) {
+	// combinations created by distribute may share a backing array, so copy before modifying
+	combinationParams := append([]v1beta1.Param{}, combination.Params...)
+	for _, param := range params {
+		if matrixParamNames[param.Name] {
+			continue
+		}
+		replaced := false
+		for i := range combinationParams {
+			if combinationParams[i].Name == param.Name {
+				combinationParams[i] = param
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			combinationParams = append(combinationParams, param)
+		}
+	}
+	combination.Params = combinationParams
+}
+
+func createIncludeCombination(i int, params []v1beta1.Param) *Combination {
+	return &Combination{
+		MatrixID: strconv.Itoa(i),
+		Params:   append([]v1beta1.Param{}, params...),
+	}
+}
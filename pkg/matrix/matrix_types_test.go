@@ -14,6 +14,7 @@ limitations under the License.
 package matrix
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -218,3 +219,51 @@ func Test_ToMap(t *testing.T) {
 		})
 	}
 }
+
+func Test_CombinationName(t *testing.T) {
+	combination := &Combination{
+		MatrixID: "0",
+		Params: []v1beta1.Param{{
+			Name:  "platform",
+			Value: v1beta1.ArrayOrString{Type: v1beta1.ParamTypeString, StringVal: "linux"},
+		}, {
+			Name:  "browser",
+			Value: v1beta1.ArrayOrString{Type: v1beta1.ParamTypeString, StringVal: "Chrome"},
+		}},
+	}
+	reordered := &Combination{
+		MatrixID: "3",
+		Params:   []v1beta1.Param{combination.Params[1], combination.Params[0]},
+	}
+	want := "pr-a-task-browser-chrome-platform-linux"
+	if d := cmp.Diff(want, combination.Name("pr-a-task")); d != "" {
+		t.Errorf("Name of the Combination did not match the expected name: %s", d)
+	}
+	if d := cmp.Diff(want, reordered.Name("pr-a-task")); d != "" {
+		t.Errorf("Name of the Combination with reordered Parameters did not match the expected name: %s", d)
+	}
+}
+
+func Test_CombinationName_Long(t *testing.T) {
+	newCombination := func(image string) *Combination {
+		return &Combination{
+			Params: []v1beta1.Param{{
+				Name:  "image",
+				Value: v1beta1.ArrayOrString{Type: v1beta1.ParamTypeString, StringVal: image},
+			}},
+		}
+	}
+	long := newCombination("registry.example.com/some/very/long/repository/path/to/an/image:" + strings.Repeat("v", 40))
+	other := newCombination("registry.example.com/some/very/long/repository/path/to/an/image:" + strings.Repeat("w", 40))
+
+	name := long.Name("pr-a-task")
+	if len(name) > 63 {
+		t.Errorf("Combination.Name() = %s, with length %d, want at most 63", name, len(name))
+	}
+	if got := long.Name("pr-a-task"); got != name {
+		t.Errorf("Combination.Name() = %s, then %s, want the same name", name, got)
+	}
+	if otherName := other.Name("pr-a-task"); otherName == name {
+		t.Errorf("Combination.Name() = %s for different Parameters, want different names", name)
+	}
+}
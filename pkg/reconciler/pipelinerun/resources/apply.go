@@ -20,7 +20,6 @@ import (
 	"context"
 	"fmt"
 	"strconv"
-	"strings"
 
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/run/v1alpha1"
@@ -30,14 +29,6 @@ import (
 	"github.com/tektoncd/pipeline/pkg/substitution"
 )
 
-const (
-	// resultsParseNumber is the value of how many parts we split from result reference. e.g.  tasks.<taskName>.results.<objectResultName>
-	resultsParseNumber = 4
-	// objectElementResultsParseNumber is the value of how many parts we split from
-	// object attribute result reference. e.g.  tasks.<taskName>.results.<objectResultName>.<individualAttribute>
-	objectElementResultsParseNumber = 5
-)
-
 // ApplyParameters applies the params from a PipelineRun.Params to a PipelineSpec.
 func ApplyParameters(ctx context.Context, p *v1beta1.PipelineSpec, pr *v1beta1.PipelineRun) *v1beta1.PipelineSpec {
 	// This assumes that the PipelineRun inputs have been validated against what the Pipeline requests.
@@ -260,121 +251,12 @@ func ApplyTaskResultsToPipelineResults(
 	results []v1beta1.PipelineResult,
 	taskRunResults map[string][]v1beta1.TaskRunResult,
 	customTaskResults map[string][]v1alpha1.RunResult) ([]v1beta1.PipelineRunResult, error) {
-	var runResults []v1beta1.PipelineRunResult
-	var invalidPipelineResults []string
-	stringReplacements := map[string]string{}
-	arrayReplacements := map[string][]string{}
-	objectReplacements := map[string]map[string]string{}
-	for _, pipelineResult := range results {
-		variablesInPipelineResult, _ := v1beta1.GetVarSubstitutionExpressionsForPipelineResult(pipelineResult)
-		if len(variablesInPipelineResult) == 0 {
-			continue
-		}
-		validPipelineResult := true
-		for _, variable := range variablesInPipelineResult {
-			if _, isMemoized := stringReplacements[variable]; isMemoized {
-				continue
-			}
-			if _, isMemoized := arrayReplacements[variable]; isMemoized {
-				continue
-			}
-			if _, isMemoized := objectReplacements[variable]; isMemoized {
-				continue
-			}
-			variableParts := strings.Split(variable, ".")
-			if variableParts[0] != v1beta1.ResultTaskPart || variableParts[2] != v1beta1.ResultResultPart {
-				validPipelineResult = false
-				invalidPipelineResults = append(invalidPipelineResults, pipelineResult.Name)
-				continue
-			}
-			switch len(variableParts) {
-			// For string result: tasks.<taskName>.results.<objectResultName>
-			// For array result: tasks.<taskName>.results.<objectResultName>[*], tasks.<taskName>.results.<objectResultName>[i]
-			// For object result: tasks.<taskName>.results.<objectResultName>[*],
-			case resultsParseNumber:
-				taskName, resultName := variableParts[1], variableParts[3]
-				resultName, stringIdx := v1beta1.ParseResultName(resultName)
-				if resultValue := taskResultValue(taskName, resultName, taskRunResults); resultValue != nil {
-					switch resultValue.Type {
-					case v1beta1.ParamTypeString:
-						stringReplacements[variable] = resultValue.StringVal
-					case v1beta1.ParamTypeArray:
-						if stringIdx != "*" {
-							intIdx, _ := strconv.Atoi(stringIdx)
-							if intIdx < len(resultValue.ArrayVal) {
-								stringReplacements[variable] = resultValue.ArrayVal[intIdx]
-							} else {
-								invalidPipelineResults = append(invalidPipelineResults, pipelineResult.Name)
-								validPipelineResult = false
-							}
-						} else {
-							arrayReplacements[substitution.StripStarVarSubExpression(variable)] = resultValue.ArrayVal
-						}
-					case v1beta1.ParamTypeObject:
-						objectReplacements[substitution.StripStarVarSubExpression(variable)] = resultValue.ObjectVal
-					}
-				} else if resultValue := runResultValue(taskName, resultName, customTaskResults); resultValue != nil {
-					stringReplacements[variable] = *resultValue
-				} else {
-					// referred array index out of bound
-					invalidPipelineResults = append(invalidPipelineResults, pipelineResult.Name)
-					validPipelineResult = false
-				}
-			// For object type result: tasks.<taskName>.results.<objectResultName>.<individualAttribute>
-			case objectElementResultsParseNumber:
-				taskName, resultName, objectKey := variableParts[1], variableParts[3], variableParts[4]
-				resultName, _ = v1beta1.ParseResultName(resultName)
-				if resultValue := taskResultValue(taskName, resultName, taskRunResults); resultValue != nil {
-					if _, ok := resultValue.ObjectVal[objectKey]; ok {
-						stringReplacements[variable] = resultValue.ObjectVal[objectKey]
-					} else {
-						// referred object key is not existent
-						invalidPipelineResults = append(invalidPipelineResults, pipelineResult.Name)
-						validPipelineResult = false
-					}
-				}
-			default:
-				invalidPipelineResults = append(invalidPipelineResults, pipelineResult.Name)
-				validPipelineResult = false
-			}
-		}
-		if validPipelineResult {
-			finalValue := pipelineResult.Value
-			finalValue.ApplyReplacements(stringReplacements, arrayReplacements, objectReplacements)
-			runResults = append(runResults, v1beta1.PipelineRunResult{
-				Name:  pipelineResult.Name,
-				Value: finalValue,
-			})
-		}
-	}
-
-	if len(invalidPipelineResults) > 0 {
-		return runResults, fmt.Errorf("invalid pipelineresults %v, the referred results don't exist", invalidPipelineResults)
-	}
-
-	return runResults, nil
-}
-
-// taskResultValue returns the result value for a given pipeline task name and result name in a map of TaskRunResults for
-// pipeline task names. It returns nil if either the pipeline task name isn't present in the map, or if there is no
-// result with the result name in the pipeline task name's slice of results.
-func taskResultValue(taskName string, resultName string, taskResults map[string][]v1beta1.TaskRunResult) *v1beta1.ArrayOrString {
-	for _, trResult := range taskResults[taskName] {
-		if trResult.Name == resultName {
-			return &trResult.Value
-		}
-	}
-	return nil
-}
-
-// runResultValue returns the result value for a given pipeline task name and result name in a map of RunResults for
-// pipeline task names. It returns nil if either the pipeline task name isn't present in the map, or if there is no
-// result with the result name in the pipeline task name's slice of results.
-func runResultValue(taskName string, resultName string, runResults map[string][]v1alpha1.RunResult) *string {
-	for _, runResult := range runResults[taskName] {
-		if runResult.Name == resultName {
-			return &runResult.Value
+	runResults := make(map[string]map[string]string, len(customTaskResults))
+	for taskName, taskResults := range customTaskResults {
+		runResults[taskName] = make(map[string]string, len(taskResults))
+		for _, r := range taskResults {
+			runResults[taskName][r.Name] = r.Value
 		}
 	}
-	return nil
+	return v1beta1.ResolvePipelineResults(results, taskRunResults, runResults)
 }
@@ -30,6 +30,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
 	"github.com/tektoncd/pipeline/pkg/remote"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"knative.dev/pkg/apis"
 	"knative.dev/pkg/kmeta"
 )
@@ -522,31 +523,21 @@ func ValidateWorkspaceBindings(p *v1beta1.PipelineSpec, pr *v1beta1.PipelineRun)
 
 // ValidateTaskRunSpecs that the TaskRunSpecs defined by a PipelineRun are correct.
 func ValidateTaskRunSpecs(p *v1beta1.PipelineSpec, pr *v1beta1.PipelineRun) error {
-	pipelineTasks := make(map[string]string)
+	pipelineTasks := sets.NewString()
 	for _, task := range p.Tasks {
-		pipelineTasks[task.Name] = task.Name
+		pipelineTasks.Insert(task.Name)
 	}
 
 	for _, task := range p.Finally {
-		pipelineTasks[task.Name] = task.Name
+		pipelineTasks.Insert(task.Name)
 	}
 
-	for _, taskrunSpec := range pr.Spec.TaskRunSpecs {
-		if _, ok := pipelineTasks[taskrunSpec.PipelineTaskName]; !ok {
-			return fmt.Errorf("PipelineRun's taskrunSpecs defined wrong taskName: %q, does not exist in Pipeline", taskrunSpec.PipelineTaskName)
-		}
-	}
-	return nil
+	return pr.Spec.ValidateTaskRunSpecs(pipelineTasks)
 }
 
 func isCustomTask(ctx context.Context, rpt ResolvedPipelineTask) bool {
-	invalidSpec := rpt.PipelineTask.TaskRef != nil && rpt.PipelineTask.TaskSpec != nil
-	isTaskRefCustomTask := rpt.PipelineTask.TaskRef != nil && rpt.PipelineTask.TaskRef.APIVersion != "" &&
-		rpt.PipelineTask.TaskRef.Kind != ""
-	isTaskSpecCustomTask := rpt.PipelineTask.TaskSpec != nil && rpt.PipelineTask.TaskSpec.APIVersion != "" &&
-		rpt.PipelineTask.TaskSpec.Kind != ""
 	cfg := config.FromContextOrDefaults(ctx)
-	return cfg.FeatureFlags.EnableCustomTasks && !invalidSpec && (isTaskRefCustomTask || isTaskSpecCustomTask)
+	return cfg.FeatureFlags.EnableCustomTasks && rpt.PipelineTask.IsCustomTask()
 }
 
 // ResolvePipelineTask retrieves a single Task's instance using the getTask to fetch
@@ -27,6 +27,7 @@ import (
 
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/container"
 	"github.com/tektoncd/pipeline/pkg/pod"
@@ -35,6 +36,12 @@ import (
 
 // ApplyParameters applies the params from a TaskRun.Input.Parameters to a TaskSpec
 func ApplyParameters(ctx context.Context, spec *v1beta1.TaskSpec, tr *v1beta1.TaskRun, defaults ...v1beta1.ParamSpec) *v1beta1.TaskSpec {
+	return ApplyParameterValues(ctx, spec, tr.Spec.Params, defaults...)
+}
+
+// ApplyParameterValues applies the given params to a TaskSpec, falling back to the defaults for the params
+// that aren't given. The TaskSpec is left untouched and a substituted copy of it is returned.
+func ApplyParameterValues(ctx context.Context, spec *v1beta1.TaskSpec, params []v1beta1.Param, defaults ...v1beta1.ParamSpec) *v1beta1.TaskSpec {
 	// This assumes that the TaskRun inputs have been validated against what the Task requests.
 
 	// stringReplacements is used for standard single-string stringReplacements, while arrayReplacements contains arrays
@@ -79,8 +86,8 @@ func ApplyParameters(ctx context.Context, spec *v1beta1.TaskSpec, tr *v1beta1.Ta
 			}
 		}
 	}
-	// Set and overwrite params with the ones from the TaskRun
-	for _, p := range tr.Spec.Params {
+	// Set and overwrite params with the given ones
+	for _, p := range params {
 		switch p.Value.Type {
 		case v1beta1.ParamTypeArray:
 			for _, pattern := range patterns {
@@ -153,39 +160,26 @@ func ApplyContexts(spec *v1beta1.TaskSpec, taskName string, tr *v1beta1.TaskRun)
 // volumes that bindings are realized with in the task spec and the PersistentVolumeClaim names for the
 // workspaces.
 func ApplyWorkspaces(ctx context.Context, spec *v1beta1.TaskSpec, declarations []v1beta1.WorkspaceDeclaration, bindings []v1beta1.WorkspaceBinding, vols map[string]corev1.Volume) *v1beta1.TaskSpec {
-	stringReplacements := map[string]string{}
-
+	v1Declarations := make([]v1.WorkspaceDeclaration, 0, len(declarations))
+	for _, declaration := range declarations {
+		v1Declarations = append(v1Declarations, v1.WorkspaceDeclaration(declaration))
+	}
+	v1Bindings := make([]v1.WorkspaceBinding, 0, len(bindings))
 	bindNames := sets.NewString()
 	for _, binding := range bindings {
+		v1Bindings = append(v1Bindings, v1.WorkspaceBinding(binding))
 		bindNames.Insert(binding.Name)
 	}
+	stringReplacements := v1.WorkspaceReplacements(v1Declarations, v1Bindings, vols)
 
-	for _, declaration := range declarations {
-		prefix := fmt.Sprintf("workspaces.%s.", declaration.Name)
-		if declaration.Optional && !bindNames.Has(declaration.Name) {
-			stringReplacements[prefix+"bound"] = "false"
-			stringReplacements[prefix+"path"] = ""
-		} else {
-			stringReplacements[prefix+"bound"] = "true"
-			alphaAPIEnabled := config.FromContextOrDefaults(ctx).FeatureFlags.EnableAPIFields == config.AlphaAPIFields
-			if alphaAPIEnabled {
+	if config.FromContextOrDefaults(ctx).FeatureFlags.EnableAPIFields == config.AlphaAPIFields {
+		for _, declaration := range declarations {
+			if !declaration.Optional || bindNames.Has(declaration.Name) {
+				prefix := fmt.Sprintf("workspaces.%s.", declaration.Name)
 				spec = applyWorkspaceMountPath(prefix+"path", spec, declaration)
-			} else {
-				stringReplacements[prefix+"path"] = declaration.GetMountPath()
 			}
 		}
 	}
-
-	for name, vol := range vols {
-		stringReplacements[fmt.Sprintf("workspaces.%s.volume", name)] = vol.Name
-	}
-	for _, binding := range bindings {
-		if binding.PersistentVolumeClaim != nil {
-			stringReplacements[fmt.Sprintf("workspaces.%s.claim", binding.Name)] = binding.PersistentVolumeClaim.ClaimName
-		} else {
-			stringReplacements[fmt.Sprintf("workspaces.%s.claim", binding.Name)] = ""
-		}
-	}
 	return ApplyReplacements(spec, stringReplacements, map[string][]string{})
 }
 
@@ -1087,6 +1087,70 @@ func TestApplyObjectParameters(t *testing.T) {
 	}
 }
 
+func TestApplyParameterValues(t *testing.T) {
+	spec := &v1beta1.TaskSpec{
+		Steps: []v1beta1.Step{{
+			Name:    "build",
+			Image:   "$(params.image)",
+			Command: []string{"build", "$(params.flags[*])"},
+			Args:    []string{"--url=$(params.repo.url)", "--revision=$(params.repo.revision)"},
+			Env: []corev1.EnvVar{{
+				Name:  "IMAGE",
+				Value: "$(params.image)",
+			}},
+			Script: "echo $(params.image)",
+		}},
+		Workspaces: []v1beta1.WorkspaceDeclaration{{
+			Name:      "source",
+			MountPath: "/workspace/$(params.image)",
+		}},
+	}
+	params := []v1beta1.Param{{
+		Name:  "flags",
+		Value: *v1beta1.NewArrayOrString("--verbose", "--debug"),
+	}, {
+		Name: "repo",
+		Value: *v1beta1.NewObject(map[string]string{
+			"url": "https://github.com/tektoncd/pipeline",
+		}),
+	}}
+	defaults := []v1beta1.ParamSpec{{
+		Name:    "image",
+		Default: v1beta1.NewArrayOrString("golang"),
+	}, {
+		Name: "repo",
+		Default: v1beta1.NewObject(map[string]string{
+			"url":      "https://github.com/tektoncd/catalog",
+			"revision": "main",
+		}),
+	}}
+	original := spec.DeepCopy()
+	want := &v1beta1.TaskSpec{
+		Steps: []v1beta1.Step{{
+			Name:    "build",
+			Image:   "golang",
+			Command: []string{"build", "--verbose", "--debug"},
+			Args:    []string{"--url=https://github.com/tektoncd/pipeline", "--revision=main"},
+			Env: []corev1.EnvVar{{
+				Name:  "IMAGE",
+				Value: "golang",
+			}},
+			Script: "echo golang",
+		}},
+		Workspaces: []v1beta1.WorkspaceDeclaration{{
+			Name:      "source",
+			MountPath: "/workspace/golang",
+		}},
+	}
+	got := resources.ApplyParameterValues(context.Background(), spec, params, defaults...)
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("ApplyParameterValues() got diff %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff(original, spec); d != "" {
+		t.Errorf("ApplyParameterValues() modified the TaskSpec %s", diff.PrintWantGot(d))
+	}
+}
+
 func TestApplyResources(t *testing.T) {
 	tests := []struct {
 		name string
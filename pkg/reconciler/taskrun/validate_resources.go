@@ -86,6 +86,9 @@ func validateParams(ctx context.Context, paramSpecs []v1beta1.ParamSpec, params
 	if missingKeysObjectParamNames := MissingKeysObjectParamNames(paramSpecs, params); len(missingKeysObjectParamNames) != 0 {
 		return fmt.Errorf("missing keys for these params which are required in ParamSpec's properties %v", missingKeysObjectParamNames)
 	}
+	if notAllowedParamsNames := notAllowedParamsNames(paramSpecs, params, matrix); len(notAllowedParamsNames) != 0 {
+		return fmt.Errorf("param values aren't allowed by the enum of these params: %s", notAllowedParamsNames)
+	}
 
 	return nil
 }
@@ -134,6 +137,34 @@ func extraParamsNames(ctx context.Context, neededParams []string, providedParams
 	return nil
 }
 
+// notAllowedParamsNames returns the names of the params whose values aren't allowed by the
+// Enum of their ParamSpec. Every value of a param in the matrix must be allowed.
+func notAllowedParamsNames(paramSpecs []v1beta1.ParamSpec, params []v1beta1.Param, matrix []v1beta1.Param) []string {
+	specs := make(map[string]v1beta1.ParamSpec, len(paramSpecs))
+	for _, spec := range paramSpecs {
+		specs[spec.Name] = spec
+	}
+	var notAllowedParamsNames []string
+	for _, param := range params {
+		if spec, ok := specs[param.Name]; ok && param.Value.Type == v1beta1.ParamTypeString && !spec.AllowsValue(param.Value.StringVal) {
+			notAllowedParamsNames = append(notAllowedParamsNames, param.Name)
+		}
+	}
+	for _, param := range matrix {
+		spec, ok := specs[param.Name]
+		if !ok {
+			continue
+		}
+		for _, value := range param.Value.ArrayVal {
+			if !spec.AllowsValue(value) {
+				notAllowedParamsNames = append(notAllowedParamsNames, param.Name)
+				break
+			}
+		}
+	}
+	return notAllowedParamsNames
+}
+
 func wrongTypeParamsNames(params []v1beta1.Param, matrix []v1beta1.Param, neededParamsTypes map[string]v1beta1.ParamType) []string {
 	// TODO(#4723): validate that $(task.taskname.result.resultname) is invalid for array and object type.
 	// It should be used to refer string and need to add [*] to refer to array or object.
@@ -290,6 +290,75 @@ func TestValidateResolvedTaskResources_InvalidParams(t *testing.T) {
 	}
 }
 
+func TestValidateResolvedTaskResources_ParamEnum(t *testing.T) {
+	ctx := context.Background()
+	rtr := &resources.ResolvedTaskResources{
+		TaskSpec: &v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{
+				Image:   "myimage",
+				Command: []string{"mycmd"},
+			}},
+			Params: []v1beta1.ParamSpec{{
+				Name:    "mode",
+				Type:    v1beta1.ParamTypeString,
+				Enum:    []string{"fast", "slow"},
+				Default: v1beta1.NewArrayOrString("slow"),
+			}},
+		},
+	}
+	tcs := []struct {
+		name    string
+		params  []v1beta1.Param
+		matrix  []v1beta1.Param
+		wantErr string
+	}{{
+		name: "default value",
+	}, {
+		name: "allowed value",
+		params: []v1beta1.Param{{
+			Name:  "mode",
+			Value: *v1beta1.NewArrayOrString("fast"),
+		}},
+	}, {
+		name: "allowed values in matrix",
+		matrix: []v1beta1.Param{{
+			Name:  "mode",
+			Value: *v1beta1.NewArrayOrString("fast", "slow"),
+		}},
+	}, {
+		name: "value not allowed",
+		params: []v1beta1.Param{{
+			Name:  "mode",
+			Value: *v1beta1.NewArrayOrString("medium"),
+		}},
+		wantErr: "invalid input params for task : param values aren't allowed by the enum of these params: [mode]",
+	}, {
+		name: "value in matrix not allowed",
+		matrix: []v1beta1.Param{{
+			Name:  "mode",
+			Value: *v1beta1.NewArrayOrString("fast", "medium"),
+		}},
+		wantErr: "invalid input params for task : param values aren't allowed by the enum of these params: [mode]",
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateResolvedTaskResources(ctx, tc.params, tc.matrix, rtr)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Errorf("Did not expect to see error when validating TaskRun with allowed param values but saw %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Expected to see error when validating TaskRun with param values not in the enum but saw none")
+			}
+			if d := cmp.Diff(tc.wantErr, err.Error()); d != "" {
+				t.Errorf("Unexpected error %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestValidateResolvedTaskResources_InvalidResources(t *testing.T) {
 	ctx := context.Background()
 	r := &resourcev1alpha1.PipelineResource{
@@ -17,6 +17,7 @@ limitations under the License.
 package substitution
 
 import (
+	"errors"
 	"fmt"
 	"regexp"
 	"strconv"
@@ -270,6 +271,25 @@ func extractVariablesFromString(s, prefix string) ([]string, bool, string) {
 	return vars, true, errString
 }
 
+// ExtractVariableNames returns the names of the variables with the given prefix that are referenced in s,
+// without any array index or object key. For example "foo" is returned for "$(params.foo)",
+// "$(params.foo[*])", "$(params.foo[0])", "$(params.foo.key)" and "$(params["foo"])". An error is
+// returned if a variable has more than two dot-separated components after the prefix, as in
+// "$(params.foo.bar.baz)", since it's not known which of them is the name.
+func ExtractVariableNames(s, prefix string) ([]string, error) {
+	vars, _, errString := extractVariablesFromString(s, prefix)
+	if errString != "" {
+		return nil, errors.New(errString)
+	}
+	names := make([]string, 0, len(vars))
+	for _, v := range vars {
+		if name := TrimArrayIndex(v); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
 func extractEntireVariablesFromString(s, prefix string) ([]string, error) {
 	pattern := fmt.Sprintf(braceMatchingRegex, prefix, parameterSubstitution, parameterSubstitution, parameterSubstitution)
 	re, err := regexp.Compile(pattern)
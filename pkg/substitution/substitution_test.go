@@ -512,6 +512,49 @@ func TestExtractParamsExpressions(t *testing.T) {
 	}
 }
 
+func TestExtractVariableNames(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{{
+		name:  "normal string",
+		input: "hello world",
+		want:  []string{},
+	}, {
+		name:  "references in all forms",
+		input: `$(params.a) $(params.b[*]) $(params.c[1]) $(params.d.key) $(params["e"]) $(params['f'])`,
+		want:  []string{"a", "b", "c", "d", "e", "f"},
+	}, {
+		name:  "other prefix",
+		input: "$(context.taskRun.name) $(params.a)",
+		want:  []string{"a"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := substitution.ExtractVariableNames(tt.input, "params")
+			if err != nil {
+				t.Fatalf("ExtractVariableNames() = %v", err)
+			}
+			if d := cmp.Diff(tt.want, got); d != "" {
+				t.Error(diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestExtractVariableNames_Error(t *testing.T) {
+	input := "$(params.a) $(params.b.c.d)"
+	want := `Invalid referencing of parameters in "$(params.a) $(params.b.c.d)"! Only two dot-separated components after the prefix "params" are allowed.`
+	got, err := substitution.ExtractVariableNames(input, "params")
+	if err == nil {
+		t.Fatalf("ExtractVariableNames() = %v, expected an error", got)
+	}
+	if d := cmp.Diff(want, err.Error()); d != "" {
+		t.Error(diff.PrintWantGot(d))
+	}
+}
+
 func TestExtractIntIndex(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -18,8 +18,11 @@ package termination
 
 import (
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"os"
+	"sort"
+	"strings"
 
 	v1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 )
@@ -65,6 +68,65 @@ func WriteMessage(path string, pro []v1beta1.PipelineResourceResult) error {
 	return f.Sync()
 }
 
+// ValidateResultsSize returns an error if the results, keyed by name, take up more than maxBytes once
+// they're encoded as task results in a termination message, as WriteMessage does. The error names the
+// largest results, which would have to be dropped for the rest to fit.
+func ValidateResultsSize(results map[string]string, maxBytes int) error {
+	if maxBytes < 0 {
+		return fmt.Errorf("the limit of %d bytes must not be negative", maxBytes)
+	}
+	entries := make([]v1beta1.PipelineResourceResult, 0, len(results))
+	sizes := make(map[string]int, len(results))
+	for name, value := range results {
+		entry := v1beta1.PipelineResourceResult{
+			Key:        name,
+			Value:      value,
+			ResultType: v1beta1.TaskRunResultType,
+		}
+		encoded, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		sizes[name] = len(encoded)
+		entries = append(entries, entry)
+	}
+	total, err := encodedSize(entries)
+	if err != nil {
+		return err
+	}
+	if total <= maxBytes {
+		return nil
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if sizes[entries[i].Key] != sizes[entries[j].Key] {
+			return sizes[entries[i].Key] > sizes[entries[j].Key]
+		}
+		return entries[i].Key < entries[j].Key
+	})
+	var offending []string
+	for remaining := total; remaining > maxBytes && len(offending) < len(entries); {
+		name := entries[len(offending)].Key
+		offending = append(offending, fmt.Sprintf("%q (%d bytes)", name, sizes[name]))
+		if remaining, err = encodedSize(entries[len(offending):]); err != nil {
+			return err
+		}
+	}
+	if len(offending) == 0 {
+		return fmt.Errorf("results take up %d bytes which is above the limit of %d bytes", total, maxBytes)
+	}
+	return fmt.Errorf("results take up %d bytes which is above the limit of %d bytes, the largest results are %s", total, maxBytes, strings.Join(offending, ", "))
+}
+
+// encodedSize returns the length of the termination message that WriteMessage writes for the results.
+func encodedSize(results []v1beta1.PipelineResourceResult) (int, error) {
+	encoded, err := json.Marshal(results)
+	if err != nil {
+		return 0, err
+	}
+	return len(encoded), nil
+}
+
 // MessageLengthError indicate the length of termination message of container is beyond 4096 which is the max length read by kubenates
 type MessageLengthError string
 
@@ -87,3 +87,65 @@ func TestMaxSizeFile(t *testing.T) {
 		t.Fatalf("Expected MessageLengthError, received: %v", err)
 	}
 }
+
+func TestValidateResultsSize(t *testing.T) {
+	// Encoded as [{"key":"digest","value":"xx...","type":1},{"key":"url","value":"yy...","type":1}],
+	// which takes up 102 bytes: 56 and 43 for the results and 3 for the brackets and the comma.
+	results := map[string]string{
+		"digest": strings.Repeat("x", 20),
+		"url":    strings.Repeat("y", 10),
+	}
+	for _, tc := range []struct {
+		name     string
+		results  map[string]string
+		maxBytes int
+		wantErr  string
+	}{{
+		name:     "just under the limit",
+		results:  results,
+		maxBytes: 103,
+	}, {
+		name:     "at the limit",
+		results:  results,
+		maxBytes: 102,
+	}, {
+		name:     "just over the limit",
+		results:  results,
+		maxBytes: 101,
+		wantErr:  `results take up 102 bytes which is above the limit of 101 bytes, the largest results are "digest" (56 bytes)`,
+	}, {
+		name:     "far over the limit",
+		results:  results,
+		maxBytes: 40,
+		wantErr:  `results take up 102 bytes which is above the limit of 40 bytes, the largest results are "digest" (56 bytes), "url" (43 bytes)`,
+	}, {
+		name:     "over the limit without being able to fit",
+		results:  results,
+		maxBytes: 1,
+		wantErr:  `results take up 102 bytes which is above the limit of 1 bytes, the largest results are "digest" (56 bytes), "url" (43 bytes)`,
+	}, {
+		name:     "no results over the limit",
+		maxBytes: 1,
+		wantErr:  `results take up 2 bytes which is above the limit of 1 bytes`,
+	}, {
+		name:     "negative limit",
+		maxBytes: -1,
+		wantErr:  `the limit of -1 bytes must not be negative`,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateResultsSize(tc.results, tc.maxBytes)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateResultsSize() returned unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("ValidateResultsSize() did not return error for results above the limit")
+			}
+			if d := cmp.Diff(tc.wantErr, err.Error()); d != "" {
+				t.Errorf("ValidateResultsSize() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}